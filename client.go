@@ -59,8 +59,12 @@ func main() {
 	defer queryConn.Close()
 
 	srv := handler.New(graph.NewExecutableSchema(graph.Config{Resolvers: &graph.Resolver{
-		CommandClient: commandClient,
-		QueryClient:   queryClient,
+		CommandClient:      commandClient,
+		QueryClient:        queryClient,
+		OrderCommandClient: pb.NewOrderServiceCommandClient(commandConn),
+		OrderQueryClient:   pb.NewOrderServiceQueryClient(queryConn),
+		CartCommandClient:  pb.NewCartServiceCommandClient(commandConn),
+		CartQueryClient:    pb.NewCartServiceQueryClient(queryConn),
 	}}))
 
 	srv.AddTransport(transport.Options{})
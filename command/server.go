@@ -2,20 +2,29 @@ package main
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"log/slog"
 	"net"
+	"net/http"
 	"os"
 	"os/signal"
 	"syscall"
 	"time"
 
+	"github.com/apache/pulsar-client-go/pulsar"
+	"github.com/gocql/gocql"
 	"github.com/joho/godotenv"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
 	"github.com/yaninyzwitty/cqrs-eccomerce-service/internal/controllers"
 	"github.com/yaninyzwitty/cqrs-eccomerce-service/internal/database"
+	"github.com/yaninyzwitty/cqrs-eccomerce-service/internal/events"
+	"github.com/yaninyzwitty/cqrs-eccomerce-service/internal/eventstore"
 	"github.com/yaninyzwitty/cqrs-eccomerce-service/internal/helpers"
+	"github.com/yaninyzwitty/cqrs-eccomerce-service/internal/lease"
 	"github.com/yaninyzwitty/cqrs-eccomerce-service/internal/messaging"
 	"github.com/yaninyzwitty/cqrs-eccomerce-service/internal/processor"
+	"github.com/yaninyzwitty/cqrs-eccomerce-service/internal/projection"
 	"github.com/yaninyzwitty/cqrs-eccomerce-service/internal/queue"
 	"github.com/yaninyzwitty/cqrs-eccomerce-service/internal/repository"
 	"github.com/yaninyzwitty/cqrs-eccomerce-service/pb"
@@ -25,6 +34,16 @@ import (
 	"google.golang.org/grpc/reflection"
 )
 
+// eventProducer is recorded as the producer of every event relayed by this service.
+const eventProducer = "command-server"
+
+// defaultRelayPollInterval applies when the config does not set relay.poll_interval.
+const defaultRelayPollInterval = 4 * time.Second
+
+// scheduledChangesSubscription is shared by the replicas applying scheduled changes, Pulsar only
+// holds delayed messages back on shared subscriptions.
+const scheduledChangesSubscription = "scheduled-changes"
+
 func main() {
 	var cfg pkg.Config
 	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
@@ -66,26 +85,49 @@ func main() {
 		os.Exit(1)
 	}
 	defer session.Close()
-	pulsarCfg := &queue.PulsarConfig{
-		URI:       cfg.Queue.Uri,
-		TopicName: cfg.Queue.Topic,
-		Token:     helpers.GetEnvOrDefault("PULSAR_TOKEN", ""),
+	eventEncoding, err := events.ParseEncoding(cfg.Queue.Encoding)
+	if err != nil {
+		slog.Error("failed to parse event encoding", "error", err)
+		os.Exit(1)
 	}
 
-	queueInstance := queue.NewPulsar(pulsarCfg)
-	client, err := queueInstance.CreatePulsarConnection(ctx)
-	if err != nil {
-		slog.Error("failed to create pulsar connection", "error", err)
+	// the memory broker only reaches consumers in the same process, the command server and the
+	// projector run apart so events relayed through it would never be projected
+	if cfg.Queue.Broker == messaging.BrokerMemory {
+		slog.Error("the memory broker only works within one process, use pulsar or kafka", "broker", cfg.Queue.Broker)
 		os.Exit(1)
 	}
-	defer client.Close()
 
-	producer, err := queueInstance.CreatePulsarProducer(ctx, client)
+	hostname, _ := os.Hostname()
+
+	brokerCfg := messaging.BrokerConfig{
+		Kind:         cfg.Queue.Broker,
+		Subscription: scheduledChangesSubscription,
+		Pulsar: queue.PulsarConfig{
+			URI:      cfg.Queue.Uri,
+			Token:    helpers.GetEnvOrDefault("PULSAR_TOKEN", ""),
+			Auth:     cfg.Queue.Pulsar.Auth,
+			Producer: cfg.Queue.Pulsar.Producer,
+			Consumer: queue.PulsarConsumerOptions{SubscriptionType: "shared"},
+		},
+		Kafka: queue.KafkaConfig{Brokers: cfg.Queue.Kafka.Brokers},
+	}
+	// the broker refuses a producer whose name is already connected to the topic, a replica taking
+	// over the relay would fail while the replica that lost the lease still holds the name. The host
+	// name stays the same when a replica restarts, so the broker still dedups the rows it sends again.
+	if brokerCfg.Pulsar.Producer.Name != "" {
+		brokerCfg.Pulsar.Producer.Name += "-" + hostname
+	}
+	if eventEncoding == events.EncodingProtobuf {
+		brokerCfg.Pulsar.ProducerSchema = pulsar.NewProtoNativeSchemaWithMessage(&pb.DomainEvent{}, nil)
+	}
+
+	broker, err := messaging.NewBroker(ctx, brokerCfg)
 	if err != nil {
-		slog.Error("failed to create pulsar producer", "error", err)
+		slog.Error("failed to connect to the message broker", "error", err)
 		os.Exit(1)
 	}
-	defer producer.Close()
+	defer broker.Close()
 
 	lis, err := net.Listen("tcp", fmt.Sprintf(":%d", cfg.CommandServer.Port))
 	if err != nil {
@@ -93,37 +135,165 @@ func main() {
 		os.Exit(1)
 	}
 
-	productContoller := controllers.NewCommandProductCommandController(session)
+	var productEventStore eventstore.EventStore
+	if cfg.EventSourcing.Products {
+		productEventStore = eventstore.NewCassandraEventStore(session)
+		slog.Info("Products are event sourced")
+	}
+
+	outboxWakeup := processor.NewWakeup()
+	productContoller := controllers.NewCommandProductCommandController(session, outboxWakeup, productEventStore)
+	inventoryController := controllers.NewInventoryCommandController(session, outboxWakeup)
+	orderController := controllers.NewOrderCommandController(session, outboxWakeup)
+	cartController := controllers.NewCartCommandController(session, outboxWakeup, cfg.Cart.IdleTTL)
 	outboxRepo := repository.NewCassandraOutboxRepository(session)
-	pulsarProducer := messaging.NewPulsarProducer(producer)
-	pm := processor.NewProcessMessage(pulsarProducer, outboxRepo)
+	pendingEvents := controllers.NewPendingEvents(session, outboxWakeup)
+	eventRegistry, err := events.NewRegistry(events.Catalog()...)
+	if err != nil {
+		slog.Error("failed to register events", "error", err)
+		os.Exit(1)
+	}
 
-	server := grpc.NewServer()
+	// producers are created when the first event is routed to their topic
+	routes := messaging.Routes{
+		Default:        cfg.Queue.Topic,
+		EventTypes:     cfg.Queue.Routes.EventTypes,
+		AggregateTypes: cfg.Queue.Routes.AggregateTypes,
+	}
+	producer, err := messaging.NewRoutingProducer(broker, routes, eventRegistry)
+	if err != nil {
+		slog.Error("invalid topic routes", "error", err)
+		os.Exit(1)
+	}
+
+	pm := processor.NewProcessMessage(producer, outboxRepo, eventRegistry, processor.Options{
+		Name:     eventProducer,
+		Encoding: eventEncoding,
+		Retry: processor.RetryPolicy{
+			MaxAttempts:    cfg.Relay.MaxAttempts,
+			InitialBackoff: cfg.Relay.InitialBackoff,
+			MaxBackoff:     cfg.Relay.MaxBackoff,
+		},
+		MaxInFlight:     cfg.Relay.MaxInFlight,
+		DelayedDelivery: broker.DelayedDelivery(),
+		// only Pulsar producers send the sequence ids the broker dedups on
+		Deduplication: cfg.Queue.Pulsar.Producer.Deduplication &&
+			(cfg.Queue.Broker == "" || cfg.Queue.Broker == messaging.BrokerPulsar),
+	})
+
+	// scheduled changes are applied by whichever replica receives them once they are due
+	scheduledTopic := routes.Topic(events.Envelope{
+		EventType:     events.ProductPriceChangeScheduled.Name(),
+		AggregateType: events.ProductPriceChangeScheduled.AggregateType(),
+	})
+	scheduledConsumer, err := broker.NewConsumer(ctx, scheduledTopic)
+	if err != nil {
+		slog.Error("failed to create scheduled changes consumer", "error", err)
+		os.Exit(1)
+	}
+	defer scheduledConsumer.Close()
+	// the topic carries other events too, only scheduled changes are deduplicated and applied
+	scheduledChanges := projection.Only(
+		projection.Idempotent(scheduledChangesSubscription,
+			repository.NewCassandraDedupRepository(session, cfg.Projector.DedupTTL),
+			controllers.NewScheduledPriceChanges(productContoller)),
+		events.ProductPriceChangeScheduled.Name(),
+	)
+	consumeCtx, stopConsuming := context.WithCancel(context.Background())
+	defer stopConsuming()
+
+	// only the replica holding the lease relays the outbox, the others stand by to take over
+	relayOwner := fmt.Sprintf("%s-%s", hostname, gocql.TimeUUID())
+	relayLease := lease.NewCassandraLease(session, "outbox-relay", relayOwner, cfg.Relay.LeaseTTL)
+
+	adminToken := helpers.GetEnvOrDefault("OUTBOX_ADMIN_TOKEN", "")
+	server := grpc.NewServer(grpc.ChainUnaryInterceptor(
+		controllers.AdminAuthInterceptor(adminToken),
+		controllers.TraceInterceptor,
+	))
 	reflection.Register(server) //use server reflection, not required
 	pb.RegisterProductServiceCommandServer(server, productContoller)
+	pb.RegisterInventoryServiceCommandServer(server, inventoryController)
+	pb.RegisterOrderServiceCommandServer(server, orderController)
+	pb.RegisterCartServiceCommandServer(server, cartController)
+	if adminToken != "" {
+		pb.RegisterOutboxAdminServiceServer(server, controllers.NewOutboxAdminController(outboxRepo, outboxWakeup))
+	} else {
+		slog.Warn("OUTBOX_ADMIN_TOKEN is not set, the outbox admin service is disabled")
+	}
 	sigChan := make(chan os.Signal, 1)
 	signal.Notify(sigChan, os.Interrupt, syscall.SIGTERM)
 	stopCH := make(chan os.Signal, 1)
 
-	// do custom polling
+	if cfg.CommandServer.MetricsPort != 0 {
+		go func() {
+			mux := http.NewServeMux()
+			mux.Handle("/metrics", promhttp.Handler())
+			slog.Info("Starting metrics server", "port", cfg.CommandServer.MetricsPort)
+			if err := http.ListenAndServe(fmt.Sprintf(":%d", cfg.CommandServer.MetricsPort), mux); err != nil {
+				slog.Error("metrics server encountered an error while serving", "error", err)
+			}
+		}()
+	}
+
+	// writes made through this server wake the relay right away, polling picks up rows written
+	// by other replicas or left behind by a crash
+	pollInterval := cfg.Relay.PollInterval
+	if pollInterval <= 0 {
+		pollInterval = defaultRelayPollInterval
+	}
 
 	go func() {
-		ticker := time.NewTicker(4 * time.Second)
+		ticker := time.NewTicker(pollInterval)
 		defer ticker.Stop()
 
 		for {
 			select {
 			case <-ticker.C:
-
-				if err := pm.ProcessMessages(context.Background()); err != nil {
-					slog.Error("failed to process messages", "error", err)
-					os.Exit(1)
+			case <-outboxWakeup.C():
+				// standby replicas only try for the lease on the ticker
+				if !time.Now().Before(relayLease.Expiry()) {
+					continue
 				}
-
 			case <-stopCH:
+				if err := relayLease.Release(context.Background()); err != nil {
+					slog.Error("failed to release relay lease", "error", err)
+				}
 				return
 			}
 
+			held, err := relayLease.Acquire(context.Background())
+			if err != nil {
+				slog.Error("failed to acquire relay lease", "error", err)
+				continue
+			}
+			if !held {
+				continue
+			}
+
+			passCtx, cancelPass := context.WithDeadline(context.Background(), relayLease.Expiry())
+			if err := pendingEvents.Sweep(passCtx); err != nil {
+				slog.Error("failed to sweep pending events", "error", err)
+			}
+			err = pm.ProcessMessages(passCtx)
+			cancelPass()
+			if errors.Is(err, context.DeadlineExceeded) {
+				slog.Warn("relay pass outlived its lease, resuming on the next tick", "error", err)
+				continue
+			}
+			if err != nil {
+				slog.Error("failed to process messages", "error", err)
+				os.Exit(1)
+			}
+		}
+	}()
+
+	go func() {
+		// a replica that stopped applying scheduled changes would keep its share of the
+		// subscription, exiting hands it to the other replicas until this one is restarted
+		if err := projection.Consume(consumeCtx, scheduledConsumer, scheduledChanges); err != nil {
+			slog.Error("scheduled changes consumer stopped with an error", "error", err)
+			os.Exit(1)
 		}
 	}()
 
@@ -134,6 +304,7 @@ func main() {
 
 		// Gracefully stop the Command gRPC server
 		server.GracefulStop()
+		stopConsuming()
 		cancel()      // Cancel context for other goroutines
 		close(stopCH) // Notify the polling goroutine to stop
 
@@ -0,0 +1,54 @@
+package graph
+
+import (
+	"fmt"
+	"strconv"
+
+	"github.com/yaninyzwitty/cqrs-eccomerce-service/graph/model"
+	"github.com/yaninyzwitty/cqrs-eccomerce-service/pb"
+)
+
+// parseCartOwner converts the optional customer id of a cart, the session id is passed through as is.
+func parseCartOwner(customerID *string, sessionID *string) (int64, string, error) {
+	var customerId int64
+	if customerID != nil {
+		id, err := strconv.ParseUint(*customerID, 10, 64)
+		if err != nil {
+			return 0, "", fmt.Errorf("error parsing customer ID: %v", err)
+		}
+		customerId = int64(id)
+	}
+
+	var sessionId string
+	if sessionID != nil {
+		sessionId = *sessionID
+	}
+	return customerId, sessionId, nil
+}
+
+func toCartModel(cart *pb.Cart) *model.Cart {
+	items := make([]*model.CartItem, len(cart.Items))
+	for i, item := range cart.Items {
+		items[i] = &model.CartItem{
+			ProductID:  strconv.FormatInt(item.ProductId, 10),
+			CategoryID: strconv.FormatInt(item.CategoryId, 10),
+			Name:       item.Name,
+			UnitPrice:  float64(item.UnitPrice),
+			Quantity:   item.Quantity,
+			Subtotal:   float64(item.Subtotal),
+			AddedAt:    item.AddedAt.AsTime(),
+		}
+	}
+
+	result := &model.Cart{
+		CartID:    cart.CartId,
+		Items:     items,
+		ItemCount: cart.ItemCount,
+		Total:     float64(cart.Total),
+	}
+	if cart.ExpiresAt != nil {
+		expiresAt := cart.ExpiresAt.AsTime()
+		result.ExpiresAt = &expiresAt
+	}
+	return result
+}
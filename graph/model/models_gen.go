@@ -3,9 +3,38 @@
 package model
 
 import (
+	"fmt"
+	"io"
+	"strconv"
 	"time"
 )
 
+type AddToCartInput struct {
+	CustomerID *string `json:"customerId,omitempty"`
+	SessionID  *string `json:"sessionId,omitempty"`
+	ProductID  string  `json:"productId"`
+	CategoryID string  `json:"categoryId"`
+	Quantity   int32   `json:"quantity"`
+}
+
+type Cart struct {
+	CartID    string      `json:"cartId"`
+	Items     []*CartItem `json:"items"`
+	ItemCount int32       `json:"itemCount"`
+	Total     float64     `json:"total"`
+	ExpiresAt *time.Time  `json:"expiresAt,omitempty"`
+}
+
+type CartItem struct {
+	ProductID  string    `json:"productId"`
+	CategoryID string    `json:"categoryId"`
+	Name       string    `json:"name"`
+	UnitPrice  float64   `json:"unitPrice"`
+	Quantity   int32     `json:"quantity"`
+	Subtotal   float64   `json:"subtotal"`
+	AddedAt    time.Time `json:"addedAt"`
+}
+
 type Category struct {
 	ID          string    `json:"id"`
 	Name        string    `json:"name"`
@@ -18,6 +47,11 @@ type CreateCategoryInput struct {
 	Description string `json:"description"`
 }
 
+type CreateOrderInput struct {
+	CustomerID string            `json:"customerId"`
+	Items      []*OrderItemInput `json:"items"`
+}
+
 type CreateProductInput struct {
 	Name        string  `json:"name"`
 	Description string  `json:"description"`
@@ -26,6 +60,28 @@ type CreateProductInput struct {
 	CategoryID  string  `json:"categoryId"`
 }
 
+type DeleteCategoryResponse struct {
+	ID                string    `json:"id"`
+	DeletedProductIds []string  `json:"deletedProductIds"`
+	DeletedAt         time.Time `json:"deletedAt"`
+}
+
+type DeleteProductResponse struct {
+	ID         string    `json:"id"`
+	CategoryID string    `json:"categoryId"`
+	DeletedAt  time.Time `json:"deletedAt"`
+}
+
+type ListCategoriesResponse struct {
+	Categories  []*Category `json:"categories"`
+	PagingState *string     `json:"pagingState,omitempty"`
+}
+
+type ListOrdersResponse struct {
+	Orders      []*Order `json:"orders"`
+	PagingState *string  `json:"pagingState,omitempty"`
+}
+
 type ListProductsResponse struct {
 	Products    []*Product `json:"products"`
 	PagingState *string    `json:"pagingState,omitempty"`
@@ -34,6 +90,44 @@ type ListProductsResponse struct {
 type Mutation struct {
 }
 
+type Order struct {
+	ID         string       `json:"id"`
+	CustomerID string       `json:"customerId"`
+	Items      []*OrderItem `json:"items"`
+	Total      float64      `json:"total"`
+	Status     OrderStatus  `json:"status"`
+	CreatedAt  time.Time    `json:"createdAt"`
+	UpdatedAt  time.Time    `json:"updatedAt"`
+}
+
+type OrderHistoryResponse struct {
+	Orders      []*OrderSummary `json:"orders"`
+	PagingState *string         `json:"pagingState,omitempty"`
+}
+
+type OrderItem struct {
+	ProductID  string  `json:"productId"`
+	CategoryID string  `json:"categoryId"`
+	Name       string  `json:"name"`
+	UnitPrice  float64 `json:"unitPrice"`
+	Quantity   int32   `json:"quantity"`
+}
+
+type OrderItemInput struct {
+	ProductID  string `json:"productId"`
+	CategoryID string `json:"categoryId"`
+	Quantity   int32  `json:"quantity"`
+}
+
+type OrderSummary struct {
+	OrderID   string      `json:"orderId"`
+	Status    OrderStatus `json:"status"`
+	Total     float64     `json:"total"`
+	ItemCount int32       `json:"itemCount"`
+	CreatedAt time.Time   `json:"createdAt"`
+	UpdatedAt time.Time   `json:"updatedAt"`
+}
+
 type Product struct {
 	ID          string    `json:"id"`
 	CategoryID  string    `json:"categoryId"`
@@ -47,3 +141,65 @@ type Product struct {
 
 type Query struct {
 }
+
+type RemoveFromCartInput struct {
+	CustomerID *string `json:"customerId,omitempty"`
+	SessionID  *string `json:"sessionId,omitempty"`
+	ProductID  string  `json:"productId"`
+	Quantity   *int32  `json:"quantity,omitempty"`
+}
+
+type UpdateProductInput struct {
+	CategoryID  string   `json:"categoryId"`
+	ProductID   string   `json:"productId"`
+	Name        *string  `json:"name,omitempty"`
+	Description *string  `json:"description,omitempty"`
+	Price       *float64 `json:"price,omitempty"`
+}
+
+type OrderStatus string
+
+const (
+	OrderStatusPending   OrderStatus = "PENDING"
+	OrderStatusPaid      OrderStatus = "PAID"
+	OrderStatusShipped   OrderStatus = "SHIPPED"
+	OrderStatusDelivered OrderStatus = "DELIVERED"
+	OrderStatusCancelled OrderStatus = "CANCELLED"
+)
+
+var AllOrderStatus = []OrderStatus{
+	OrderStatusPending,
+	OrderStatusPaid,
+	OrderStatusShipped,
+	OrderStatusDelivered,
+	OrderStatusCancelled,
+}
+
+func (e OrderStatus) IsValid() bool {
+	switch e {
+	case OrderStatusPending, OrderStatusPaid, OrderStatusShipped, OrderStatusDelivered, OrderStatusCancelled:
+		return true
+	}
+	return false
+}
+
+func (e OrderStatus) String() string {
+	return string(e)
+}
+
+func (e *OrderStatus) UnmarshalGQL(v any) error {
+	str, ok := v.(string)
+	if !ok {
+		return fmt.Errorf("enums must be strings")
+	}
+
+	*e = OrderStatus(str)
+	if !e.IsValid() {
+		return fmt.Errorf("%s is not a valid OrderStatus", str)
+	}
+	return nil
+}
+
+func (e OrderStatus) MarshalGQL(w io.Writer) {
+	fmt.Fprint(w, strconv.Quote(e.String()))
+}
@@ -0,0 +1,42 @@
+package graph
+
+import (
+	"strconv"
+	"strings"
+
+	"github.com/yaninyzwitty/cqrs-eccomerce-service/graph/model"
+	"github.com/yaninyzwitty/cqrs-eccomerce-service/pb"
+)
+
+const orderStatusPrefix = "ORDER_STATUS_"
+
+func toOrderStatus(status model.OrderStatus) pb.OrderStatus {
+	return pb.OrderStatus(pb.OrderStatus_value[orderStatusPrefix+string(status)])
+}
+
+func fromOrderStatus(status pb.OrderStatus) model.OrderStatus {
+	return model.OrderStatus(strings.TrimPrefix(status.String(), orderStatusPrefix))
+}
+
+func toOrderModel(order *pb.Order) *model.Order {
+	items := make([]*model.OrderItem, len(order.Items))
+	for i, item := range order.Items {
+		items[i] = &model.OrderItem{
+			ProductID:  strconv.FormatInt(item.ProductId, 10),
+			CategoryID: strconv.FormatInt(item.CategoryId, 10),
+			Name:       item.Name,
+			UnitPrice:  float64(item.UnitPrice),
+			Quantity:   item.Quantity,
+		}
+	}
+
+	return &model.Order{
+		ID:         strconv.FormatInt(order.Id, 10),
+		CustomerID: strconv.FormatInt(order.CustomerId, 10),
+		Items:      items,
+		Total:      float64(order.Total),
+		Status:     fromOrderStatus(order.Status),
+		CreatedAt:  order.CreatedAt.AsTime(),
+		UpdatedAt:  order.UpdatedAt.AsTime(),
+	}
+}
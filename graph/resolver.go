@@ -9,4 +9,10 @@ import "github.com/yaninyzwitty/cqrs-eccomerce-service/pb"
 type Resolver struct {
 	CommandClient pb.ProductServiceCommandClient
 	QueryClient   pb.ProductServiceQueryClient
+
+	OrderCommandClient pb.OrderServiceCommandClient
+	OrderQueryClient   pb.OrderServiceQueryClient
+
+	CartCommandClient pb.CartServiceCommandClient
+	CartQueryClient   pb.CartServiceQueryClient
 }
@@ -12,6 +12,7 @@ import (
 	"github.com/yaninyzwitty/cqrs-eccomerce-service/graph/model"
 	"github.com/yaninyzwitty/cqrs-eccomerce-service/internal/helpers"
 	"github.com/yaninyzwitty/cqrs-eccomerce-service/pb"
+	"google.golang.org/protobuf/types/known/fieldmaskpb"
 )
 
 // CreateProduct is the resolver for the createProduct field.
@@ -66,6 +67,217 @@ func (r *mutationResolver) CreateCategory(ctx context.Context, input model.Creat
 	}, nil
 }
 
+// UpdateProduct is the resolver for the updateProduct field.
+func (r *mutationResolver) UpdateProduct(ctx context.Context, input model.UpdateProductInput) (*model.Product, error) {
+	categoryIdInt, err := strconv.ParseUint(input.CategoryID, 10, 64)
+	if err != nil {
+		return nil, fmt.Errorf("error parsing category ID: %v", err)
+	}
+	productIdInt, err := strconv.ParseUint(input.ProductID, 10, 64)
+	if err != nil {
+		return nil, fmt.Errorf("error parsing product ID: %v", err)
+	}
+
+	// only the fields present in the input end up in the update mask
+	updateProductRequest := &pb.UpdateProductRequest{
+		CategoryId: int64(categoryIdInt),
+		ProductId:  int64(productIdInt),
+		UpdateMask: &fieldmaskpb.FieldMask{},
+	}
+	if input.Name != nil {
+		updateProductRequest.Name = *input.Name
+		updateProductRequest.UpdateMask.Paths = append(updateProductRequest.UpdateMask.Paths, "name")
+	}
+	if input.Description != nil {
+		updateProductRequest.Description = *input.Description
+		updateProductRequest.UpdateMask.Paths = append(updateProductRequest.UpdateMask.Paths, "description")
+	}
+	if input.Price != nil {
+		updateProductRequest.Price = float32(*input.Price)
+		updateProductRequest.UpdateMask.Paths = append(updateProductRequest.UpdateMask.Paths, "price")
+	}
+
+	updatedProductRes, err := r.CommandClient.UpdateProduct(ctx, updateProductRequest)
+	if err != nil {
+		return nil, fmt.Errorf("error updating product: %v", err)
+	}
+
+	return &model.Product{
+		ID:          fmt.Sprintf("%d", updatedProductRes.Product.Id),
+		CategoryID:  fmt.Sprintf("%d", updatedProductRes.Product.CategoryId),
+		Name:        updatedProductRes.Product.Name,
+		Description: updatedProductRes.Product.Description,
+		Price:       float64(updatedProductRes.Product.Price),
+		Stock:       updatedProductRes.Product.Stock,
+		CreatedAt:   updatedProductRes.Product.CreatedAt.AsTime(),
+		UpdatedAt:   updatedProductRes.Product.UpdatedAt.AsTime(),
+	}, nil
+}
+
+// DeleteProduct is the resolver for the deleteProduct field.
+func (r *mutationResolver) DeleteProduct(ctx context.Context, categoryID string, productID string) (*model.DeleteProductResponse, error) {
+	categoryIdInt, err := strconv.ParseUint(categoryID, 10, 64)
+	if err != nil {
+		return nil, fmt.Errorf("error parsing category ID: %v", err)
+	}
+	productIdInt, err := strconv.ParseUint(productID, 10, 64)
+	if err != nil {
+		return nil, fmt.Errorf("error parsing product ID: %v", err)
+	}
+
+	deletedProductRes, err := r.CommandClient.DeleteProduct(ctx, &pb.DeleteProductRequest{
+		CategoryId: int64(categoryIdInt),
+		ProductId:  int64(productIdInt),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("error deleting product: %v", err)
+	}
+
+	return &model.DeleteProductResponse{
+		ID:         strconv.FormatInt(deletedProductRes.Id, 10),
+		CategoryID: strconv.FormatInt(deletedProductRes.CategoryId, 10),
+		DeletedAt:  deletedProductRes.DeletedAt.AsTime(),
+	}, nil
+}
+
+// DeleteCategory is the resolver for the deleteCategory field.
+func (r *mutationResolver) DeleteCategory(ctx context.Context, id string, cascade *bool) (*model.DeleteCategoryResponse, error) {
+	categoryId, err := strconv.ParseUint(id, 10, 64)
+	if err != nil {
+		return nil, fmt.Errorf("error parsing category ID: %v", err)
+	}
+
+	deletedCategoryRes, err := r.CommandClient.DeleteCategory(ctx, &pb.DeleteCategoryRequest{
+		Id:      int64(categoryId),
+		Cascade: cascade != nil && *cascade,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("error deleting category: %v", err)
+	}
+
+	deletedProductIds := make([]string, len(deletedCategoryRes.DeletedProductIds))
+	for i, productId := range deletedCategoryRes.DeletedProductIds {
+		deletedProductIds[i] = strconv.FormatInt(productId, 10)
+	}
+
+	return &model.DeleteCategoryResponse{
+		ID:                strconv.FormatInt(deletedCategoryRes.Id, 10),
+		DeletedProductIds: deletedProductIds,
+		DeletedAt:         deletedCategoryRes.DeletedAt.AsTime(),
+	}, nil
+}
+
+// CreateOrder is the resolver for the createOrder field.
+func (r *mutationResolver) CreateOrder(ctx context.Context, input model.CreateOrderInput) (*model.Order, error) {
+	customerId, err := strconv.ParseUint(input.CustomerID, 10, 64)
+	if err != nil {
+		return nil, fmt.Errorf("error parsing customer ID: %v", err)
+	}
+
+	items := make([]*pb.CreateOrderItem, len(input.Items))
+	for i, item := range input.Items {
+		productId, err := strconv.ParseUint(item.ProductID, 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("error parsing product ID: %v", err)
+		}
+		categoryId, err := strconv.ParseUint(item.CategoryID, 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("error parsing category ID: %v", err)
+		}
+		items[i] = &pb.CreateOrderItem{
+			ProductId:  int64(productId),
+			CategoryId: int64(categoryId),
+			Quantity:   item.Quantity,
+		}
+	}
+
+	createdOrderRes, err := r.OrderCommandClient.CreateOrder(ctx, &pb.CreateOrderRequest{
+		CustomerId: int64(customerId),
+		Items:      items,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("error creating order: %v", err)
+	}
+
+	return toOrderModel(createdOrderRes.Order), nil
+}
+
+// UpdateOrderStatus is the resolver for the updateOrderStatus field.
+func (r *mutationResolver) UpdateOrderStatus(ctx context.Context, id string, status model.OrderStatus) (*model.Order, error) {
+	orderId, err := strconv.ParseUint(id, 10, 64)
+	if err != nil {
+		return nil, fmt.Errorf("error parsing order ID: %v", err)
+	}
+
+	updatedOrderRes, err := r.OrderCommandClient.UpdateOrderStatus(ctx, &pb.UpdateOrderStatusRequest{
+		Id:     int64(orderId),
+		Status: toOrderStatus(status),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("error updating order status: %v", err)
+	}
+
+	return toOrderModel(updatedOrderRes.Order), nil
+}
+
+// AddToCart is the resolver for the addToCart field.
+func (r *mutationResolver) AddToCart(ctx context.Context, input model.AddToCartInput) (*model.Cart, error) {
+	customerId, sessionId, err := parseCartOwner(input.CustomerID, input.SessionID)
+	if err != nil {
+		return nil, err
+	}
+	productId, err := strconv.ParseUint(input.ProductID, 10, 64)
+	if err != nil {
+		return nil, fmt.Errorf("error parsing product ID: %v", err)
+	}
+	categoryId, err := strconv.ParseUint(input.CategoryID, 10, 64)
+	if err != nil {
+		return nil, fmt.Errorf("error parsing category ID: %v", err)
+	}
+
+	cartRes, err := r.CartCommandClient.AddToCart(ctx, &pb.AddToCartRequest{
+		CustomerId: customerId,
+		SessionId:  sessionId,
+		ProductId:  int64(productId),
+		CategoryId: int64(categoryId),
+		Quantity:   input.Quantity,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("error adding to cart: %v", err)
+	}
+
+	return toCartModel(cartRes.Cart), nil
+}
+
+// RemoveFromCart is the resolver for the removeFromCart field.
+func (r *mutationResolver) RemoveFromCart(ctx context.Context, input model.RemoveFromCartInput) (*model.Cart, error) {
+	customerId, sessionId, err := parseCartOwner(input.CustomerID, input.SessionID)
+	if err != nil {
+		return nil, err
+	}
+	productId, err := strconv.ParseUint(input.ProductID, 10, 64)
+	if err != nil {
+		return nil, fmt.Errorf("error parsing product ID: %v", err)
+	}
+
+	var quantity int32
+	if input.Quantity != nil {
+		quantity = *input.Quantity
+	}
+
+	cartRes, err := r.CartCommandClient.RemoveFromCart(ctx, &pb.RemoveFromCartRequest{
+		CustomerId: customerId,
+		SessionId:  sessionId,
+		ProductId:  int64(productId),
+		Quantity:   quantity,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("error removing from cart: %v", err)
+	}
+
+	return toCartModel(cartRes.Cart), nil
+}
+
 // GetProduct is the resolver for the getProduct field.
 func (r *queryResolver) GetProduct(ctx context.Context, categoryID string, productID string) (*model.Product, error) {
 	categoryIdInt, err := strconv.ParseUint(categoryID, 10, 64)
@@ -122,6 +334,37 @@ func (r *queryResolver) GetCategory(ctx context.Context, id string) (*model.Cate
 	}, nil
 }
 
+// ListCategories is the resolver for the listCategories field.
+func (r *queryResolver) ListCategories(ctx context.Context, pagingState *string, pageSize *int32) (*model.ListCategoriesResponse, error) {
+	limit := int32(10)
+	if pageSize != nil && *pageSize > 0 {
+		limit = *pageSize
+	}
+
+	resp, err := r.QueryClient.ListCategories(ctx, &pb.ListCategoriesRequest{
+		PagingState: helpers.DecodePagingState(pagingState),
+		PageSize:    limit,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch categories: %v", err)
+	}
+
+	categories := make([]*model.Category, len(resp.Categories))
+	for i, c := range resp.Categories {
+		categories[i] = &model.Category{
+			ID:          strconv.FormatInt(c.Id, 10),
+			Name:        c.Name,
+			Description: c.Description,
+			CreatedAt:   c.CreatedAt.AsTime(),
+		}
+	}
+
+	return &model.ListCategoriesResponse{
+		Categories:  categories,
+		PagingState: helpers.EncodePagingState(resp.PagingState),
+	}, nil
+}
+
 // ListProducts is the resolver for the listProducts field.
 func (r *queryResolver) ListProducts(ctx context.Context, categoryID string, pagingState *string, pageSize *int32) (*model.ListProductsResponse, error) {
 	if categoryID == "" {
@@ -178,6 +421,104 @@ func (r *queryResolver) ListProducts(ctx context.Context, categoryID string, pag
 
 }
 
+// GetOrder is the resolver for the getOrder field.
+func (r *queryResolver) GetOrder(ctx context.Context, id string) (*model.Order, error) {
+	orderId, err := strconv.ParseUint(id, 10, 64)
+	if err != nil {
+		return nil, fmt.Errorf("error parsing order ID: %v", err)
+	}
+
+	orderRes, err := r.OrderQueryClient.GetOrder(ctx, &pb.GetOrderRequest{Id: int64(orderId)})
+	if err != nil {
+		return nil, fmt.Errorf("error getting order: %v", err)
+	}
+
+	return toOrderModel(orderRes.Order), nil
+}
+
+// GetOrders is the resolver for the getOrders field.
+func (r *queryResolver) GetOrders(ctx context.Context, pagingState *string, pageSize *int32) (*model.ListOrdersResponse, error) {
+	limit := int32(10)
+	if pageSize != nil && *pageSize > 0 {
+		limit = *pageSize
+	}
+
+	resp, err := r.OrderQueryClient.GetOrders(ctx, &pb.GetOrdersRequest{
+		PagingState: helpers.DecodePagingState(pagingState),
+		PageSize:    limit,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch orders: %v", err)
+	}
+
+	orders := make([]*model.Order, len(resp.Orders))
+	for i, o := range resp.Orders {
+		orders[i] = toOrderModel(o)
+	}
+
+	return &model.ListOrdersResponse{
+		Orders:      orders,
+		PagingState: helpers.EncodePagingState(resp.PagingState),
+	}, nil
+}
+
+// GetOrderHistory is the resolver for the getOrderHistory field.
+func (r *queryResolver) GetOrderHistory(ctx context.Context, customerID string, pagingState *string, pageSize *int32) (*model.OrderHistoryResponse, error) {
+	customerId, err := strconv.ParseUint(customerID, 10, 64)
+	if err != nil {
+		return nil, fmt.Errorf("error parsing customer ID: %v", err)
+	}
+
+	limit := int32(10)
+	if pageSize != nil && *pageSize > 0 {
+		limit = *pageSize
+	}
+
+	resp, err := r.OrderQueryClient.GetOrderHistory(ctx, &pb.GetOrderHistoryRequest{
+		CustomerId:  int64(customerId),
+		PagingState: helpers.DecodePagingState(pagingState),
+		PageSize:    limit,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch order history: %v", err)
+	}
+
+	orders := make([]*model.OrderSummary, len(resp.Orders))
+	for i, o := range resp.Orders {
+		orders[i] = &model.OrderSummary{
+			OrderID:   strconv.FormatInt(o.OrderId, 10),
+			Status:    fromOrderStatus(o.Status),
+			Total:     float64(o.Total),
+			ItemCount: o.ItemCount,
+			CreatedAt: o.CreatedAt.AsTime(),
+			UpdatedAt: o.UpdatedAt.AsTime(),
+		}
+	}
+
+	return &model.OrderHistoryResponse{
+		Orders:      orders,
+		PagingState: helpers.EncodePagingState(resp.PagingState),
+	}, nil
+}
+
+// GetCart is the resolver for the getCart field.
+func (r *queryResolver) GetCart(ctx context.Context, customerID *string, sessionID *string) (*model.Cart, error) {
+	customerId, sessionId, err := parseCartOwner(customerID, sessionID)
+	if err != nil {
+		return nil, err
+	}
+
+	cartRes, err := r.CartQueryClient.GetCart(ctx, &pb.GetCartRequest{
+		CustomerId: customerId,
+		SessionId:  sessionId,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("error getting cart: %v", err)
+	}
+
+	return toCartModel(cartRes.Cart), nil
+}
+
 // Mutation returns MutationResolver implementation.
 func (r *Resolver) Mutation() MutationResolver { return &mutationResolver{r} }
 
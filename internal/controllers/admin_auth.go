@@ -0,0 +1,32 @@
+package controllers
+
+import (
+	"context"
+	"crypto/subtle"
+	"strings"
+
+	"github.com/yaninyzwitty/cqrs-eccomerce-service/pb"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+// AdminAuthInterceptor rejects calls to the outbox admin service that do not carry token as a
+// bearer credential, calls to the other services pass through.
+func AdminAuthInterceptor(token string) grpc.UnaryServerInterceptor {
+	adminMethods := "/" + pb.OutboxAdminService_ServiceDesc.ServiceName + "/"
+
+	return func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+		if !strings.HasPrefix(info.FullMethod, adminMethods) {
+			return handler(ctx, req)
+		}
+
+		md, _ := metadata.FromIncomingContext(ctx)
+		credential := strings.TrimPrefix(firstValue(md, "authorization"), "Bearer ")
+		if token == "" || subtle.ConstantTimeCompare([]byte(credential), []byte(token)) != 1 {
+			return nil, status.Errorf(codes.Unauthenticated, "admin credential required")
+		}
+		return handler(ctx, req)
+	}
+}
@@ -0,0 +1,247 @@
+package controllers
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	"github.com/gocql/gocql"
+	"github.com/yaninyzwitty/cqrs-eccomerce-service/internal/events"
+	"github.com/yaninyzwitty/cqrs-eccomerce-service/pb"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// defaultCartIdleTTL applies when the config does not set cart.idle_ttl.
+const defaultCartIdleTTL = 72 * time.Hour
+
+// CartCommandController rewrites carts in read-modify-write cycles. Every write is a conditional
+// batch on the version of the cart it read, so concurrent changes of one cart cannot overwrite
+// each other, the loser fails with Aborted.
+type CartCommandController struct {
+	pb.UnsafeCartServiceCommandServer
+	session *gocql.Session
+	pending *PendingEvents
+	idleTTL time.Duration
+}
+
+func NewCartCommandController(session *gocql.Session, outbox OutboxNotifier, idleTTL time.Duration) *CartCommandController {
+	if idleTTL <= 0 {
+		idleTTL = defaultCartIdleTTL
+	}
+	return &CartCommandController{session: session, pending: NewPendingEvents(session, outbox), idleTTL: idleTTL}
+}
+
+func (c *CartCommandController) AddToCart(ctx context.Context, req *pb.AddToCartRequest) (*pb.AddToCartResponse, error) {
+	cartId, err := cartID(req.CustomerId, req.SessionId)
+	if err != nil {
+		return nil, err
+	}
+	if req.ProductId == 0 || req.CategoryId == 0 || req.Quantity <= 0 {
+		return nil, status.Errorf(codes.InvalidArgument, "product id, category id and a positive quantity are required")
+	}
+
+	var name string
+	var price float32
+	err = c.session.Query(
+		`SELECT name, price FROM products_keyspace_v3.products WHERE category_id = ? AND id = ?`,
+		req.CategoryId, req.ProductId,
+	).WithContext(ctx).Scan(&name, &price)
+	if err != nil {
+		if err == gocql.ErrNotFound {
+			return nil, status.Errorf(codes.NotFound, "product not found")
+		}
+		return nil, status.Errorf(codes.Internal, "failed to fetch product: %v", err)
+	}
+
+	key := rowKey{cartId}
+	if err := c.pending.resume(ctx, pendingCarts, key); err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to finish pending cart events: %v", err)
+	}
+	version, err := c.cartVersion(ctx, cartId)
+	if err != nil {
+		return nil, err
+	}
+	items, _, err := getCartItems(ctx, c.session, cartId)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+
+	var added *pb.CartItem
+	for _, item := range items {
+		if item.ProductId == req.ProductId {
+			added = item
+			break
+		}
+	}
+	if added == nil {
+		added = &pb.CartItem{
+			ProductId:  req.ProductId,
+			CategoryId: req.CategoryId,
+			AddedAt:    timestamppb.New(now),
+		}
+		items = append(items, added)
+	}
+	added.Name = name
+	added.UnitPrice = price
+	added.Quantity += req.Quantity
+
+	row, err := newOutboxRow(ctx, now, events.CartItemAdded, &pb.CartItemChanged{
+		CartId:   cartId,
+		Item:     added,
+		Quantity: req.Quantity,
+	})
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to marshal cart change: %v", err)
+	}
+
+	batch := c.session.NewBatch(gocql.LoggedBatch).WithContext(ctx)
+	c.refreshCart(batch, cartId, items)
+	if err := c.writeCart(ctx, batch, cartId, version, row); err != nil {
+		return nil, err
+	}
+
+	return &pb.AddToCartResponse{Cart: newCart(cartId, items, now.Add(c.idleTTL))}, nil
+}
+
+func (c *CartCommandController) RemoveFromCart(ctx context.Context, req *pb.RemoveFromCartRequest) (*pb.RemoveFromCartResponse, error) {
+	cartId, err := cartID(req.CustomerId, req.SessionId)
+	if err != nil {
+		return nil, err
+	}
+	if req.ProductId == 0 || req.Quantity < 0 {
+		return nil, status.Errorf(codes.InvalidArgument, "product id is required and quantity cannot be negative")
+	}
+
+	key := rowKey{cartId}
+	if err := c.pending.resume(ctx, pendingCarts, key); err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to finish pending cart events: %v", err)
+	}
+	version, err := c.cartVersion(ctx, cartId)
+	if err != nil {
+		return nil, err
+	}
+	items, _, err := getCartItems(ctx, c.session, cartId)
+	if err != nil {
+		return nil, err
+	}
+
+	var removed *pb.CartItem
+	remaining := make([]*pb.CartItem, 0, len(items))
+	for _, item := range items {
+		if item.ProductId == req.ProductId {
+			removed = item
+			continue
+		}
+		remaining = append(remaining, item)
+	}
+	if removed == nil {
+		return nil, status.Errorf(codes.NotFound, "product is not in the cart")
+	}
+
+	quantity := req.Quantity
+	if quantity == 0 || quantity >= removed.Quantity {
+		quantity = removed.Quantity
+	}
+
+	now := time.Now()
+	batch := c.session.NewBatch(gocql.LoggedBatch).WithContext(ctx)
+
+	if quantity < removed.Quantity {
+		removed.Quantity -= quantity
+		remaining = append(remaining, removed)
+	} else {
+		removed.Quantity = 0
+		batch.Query(
+			`DELETE FROM products_keyspace_v3.carts WHERE cart_id = ? AND product_id = ?`,
+			cartId, req.ProductId,
+		)
+	}
+
+	row, err := newOutboxRow(ctx, now, events.CartItemRemoved, &pb.CartItemChanged{
+		CartId:   cartId,
+		Item:     removed,
+		Quantity: quantity,
+	})
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to marshal cart change: %v", err)
+	}
+
+	c.refreshCart(batch, cartId, remaining)
+	if err := c.writeCart(ctx, batch, cartId, version, row); err != nil {
+		return nil, err
+	}
+
+	return &pb.RemoveFromCartResponse{Cart: newCart(cartId, remaining, now.Add(c.idleTTL))}, nil
+}
+
+// refreshCart rewrites every item of the cart so the whole cart expires idleTTL after its last change.
+func (c *CartCommandController) refreshCart(batch *gocql.Batch, cartId string, items []*pb.CartItem) {
+	for _, item := range items {
+		batch.Query(
+			`INSERT INTO products_keyspace_v3.carts 
+			(cart_id, product_id, category_id, name, unit_price, quantity, added_at) 
+			VALUES (?, ?, ?, ?, ?, ?, ?) 
+			USING TTL ?`,
+			cartId, item.ProductId, item.CategoryId, item.Name, item.UnitPrice, item.Quantity, item.AddedAt.AsTime(),
+			int(c.idleTTL.Seconds()),
+		)
+	}
+}
+
+// cartVersion reads the version of a cart, nil when the cart is empty or expired.
+func (c *CartCommandController) cartVersion(ctx context.Context, cartId string) (*int, error) {
+	var version *int
+	err := c.session.Query(
+		`SELECT version FROM products_keyspace_v3.carts WHERE cart_id = ? LIMIT 1`,
+		cartId,
+	).WithContext(ctx).Scan(&version)
+	if err != nil && err != gocql.ErrNotFound {
+		return nil, status.Errorf(codes.Internal, "failed to fetch cart version: %v", err)
+	}
+	return version, nil
+}
+
+// writeCart applies batch if the cart is still at version and records the event of the change. The
+// conditional batch cannot hold the outbox row, so the event goes through PendingEvents.
+func (c *CartCommandController) writeCart(ctx context.Context, batch *gocql.Batch, cartId string, version *int, row outboxRow) error {
+	pending, err := c.pending.record(ctx, pendingCarts, rowKey{cartId}, row)
+	if err != nil {
+		return status.Errorf(codes.Internal, "failed to update cart: %v", err)
+	}
+
+	next := 1
+	if version != nil {
+		next = *version + 1
+	}
+	batch.Query(
+		`UPDATE products_keyspace_v3.carts 
+		USING TTL ? 
+		SET version = ?, pending_events = pending_events + ? 
+		WHERE cart_id = ? 
+		IF version = ?`,
+		int(c.idleTTL.Seconds()), next, []gocql.UUID{row.ID}, cartId, version,
+	)
+
+	applied, iter, err := c.session.MapExecuteBatchCAS(batch, map[string]interface{}{})
+	if iter != nil {
+		iter.Close()
+	}
+	if err != nil {
+		return status.Errorf(codes.Internal, "failed to update cart: %v", err)
+	}
+	if !applied {
+		c.pending.discard(ctx, pending)
+		return status.Errorf(codes.Aborted, "cart was changed concurrently, try again")
+	}
+
+	// the cart changed and its event is marked on it, the sweep or the next change of the cart
+	// writes the event if this fails
+	if err := c.pending.finish(ctx, pending); err != nil {
+		slog.Warn("Cart event left pending", "error", err, "cartID", cartId)
+	}
+	return nil
+}
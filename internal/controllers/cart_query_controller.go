@@ -0,0 +1,101 @@
+package controllers
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/gocql/gocql"
+	"github.com/yaninyzwitty/cqrs-eccomerce-service/pb"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+type CartQueryController struct {
+	pb.UnimplementedCartServiceQueryServer
+	session *gocql.Session
+}
+
+func NewCartQueryController(session *gocql.Session) *CartQueryController {
+	return &CartQueryController{session: session}
+}
+
+func (c *CartQueryController) GetCart(ctx context.Context, req *pb.GetCartRequest) (*pb.GetCartResponse, error) {
+	cartId, err := cartID(req.CustomerId, req.SessionId)
+	if err != nil {
+		return nil, err
+	}
+
+	items, expiresAt, err := getCartItems(ctx, c.session, cartId)
+	if err != nil {
+		return nil, err
+	}
+
+	return &pb.GetCartResponse{Cart: newCart(cartId, items, expiresAt)}, nil
+}
+
+// cartID builds the partition key of a cart, customers and anonymous sessions never share a cart.
+func cartID(customerId int64, sessionId string) (string, error) {
+	switch {
+	case customerId != 0 && sessionId != "":
+		return "", status.Errorf(codes.InvalidArgument, "only one of customer id or session id can be set")
+	case customerId != 0:
+		return fmt.Sprintf("customer:%d", customerId), nil
+	case sessionId != "":
+		return "session:" + sessionId, nil
+	default:
+		return "", status.Errorf(codes.InvalidArgument, "customer id or session id is required")
+	}
+}
+
+// getCartItems reads the items of a cart along with the time the cart expires if left untouched.
+func getCartItems(ctx context.Context, session *gocql.Session, cartId string) ([]*pb.CartItem, time.Time, error) {
+	iter := session.Query(
+		`SELECT product_id, category_id, name, unit_price, quantity, added_at, TTL(quantity) 
+		FROM products_keyspace_v3.carts 
+		WHERE cart_id = ?`,
+		cartId,
+	).WithContext(ctx).Iter()
+
+	now := time.Now()
+	var items []*pb.CartItem
+	var expiresAt time.Time
+	for {
+		var item pb.CartItem
+		var addedAt time.Time
+		var ttl int
+		if !iter.Scan(&item.ProductId, &item.CategoryId, &item.Name, &item.UnitPrice, &item.Quantity, &addedAt, &ttl) {
+			break
+		}
+		// a cart whose items were all removed keeps only its static columns
+		if item.ProductId == 0 {
+			continue
+		}
+		item.AddedAt = timestamppb.New(addedAt)
+		items = append(items, &item)
+
+		if itemExpiresAt := now.Add(time.Duration(ttl) * time.Second); itemExpiresAt.After(expiresAt) {
+			expiresAt = itemExpiresAt
+		}
+	}
+
+	if err := iter.Close(); err != nil {
+		return nil, time.Time{}, status.Errorf(codes.Internal, "failed to fetch cart: %v", err)
+	}
+	return items, expiresAt, nil
+}
+
+// newCart fills in the subtotals and totals of a cart.
+func newCart(cartId string, items []*pb.CartItem, expiresAt time.Time) *pb.Cart {
+	cart := &pb.Cart{CartId: cartId, Items: items}
+	for _, item := range items {
+		item.Subtotal = item.UnitPrice * float32(item.Quantity)
+		cart.ItemCount += item.Quantity
+		cart.Total += item.Subtotal
+	}
+	if len(items) > 0 {
+		cart.ExpiresAt = timestamppb.New(expiresAt)
+	}
+	return cart
+}
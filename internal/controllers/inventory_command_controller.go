@@ -0,0 +1,150 @@
+package controllers
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	"github.com/gocql/gocql"
+	"github.com/yaninyzwitty/cqrs-eccomerce-service/internal/events"
+	"github.com/yaninyzwitty/cqrs-eccomerce-service/pb"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// maxStockUpdateAttempts bounds the compare-and-set retries when several writers race on one product.
+const maxStockUpdateAttempts = 5
+
+type InventoryCommandController struct {
+	pb.UnsafeInventoryServiceCommandServer
+	session *gocql.Session
+	pending *PendingEvents
+}
+
+func NewInventoryCommandController(session *gocql.Session, outbox OutboxNotifier) *InventoryCommandController {
+	return &InventoryCommandController{session: session, pending: NewPendingEvents(session, outbox)}
+}
+
+func (c *InventoryCommandController) ReserveStock(ctx context.Context, req *pb.ReserveStockRequest) (*pb.ReserveStockResponse, error) {
+	if req.ProductId == 0 || req.CategoryId == 0 || req.Quantity <= 0 {
+		return nil, status.Errorf(codes.InvalidArgument, "product id, category id and a positive quantity are required")
+	}
+
+	stock, err := c.updateStock(ctx, req.ProductId, req.CategoryId, events.InventoryReserved, req.Quantity, "", func(stock *pb.Stock) error {
+		if stock.StockCount < req.Quantity {
+			return status.Errorf(codes.FailedPrecondition, "insufficient stock: %d available, %d requested", stock.StockCount, req.Quantity)
+		}
+		stock.StockCount -= req.Quantity
+		stock.ReservedCount += req.Quantity
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &pb.ReserveStockResponse{Stock: stock}, nil
+}
+
+func (c *InventoryCommandController) ReleaseStock(ctx context.Context, req *pb.ReleaseStockRequest) (*pb.ReleaseStockResponse, error) {
+	if req.ProductId == 0 || req.CategoryId == 0 || req.Quantity <= 0 {
+		return nil, status.Errorf(codes.InvalidArgument, "product id, category id and a positive quantity are required")
+	}
+
+	stock, err := c.updateStock(ctx, req.ProductId, req.CategoryId, events.InventoryReleased, req.Quantity, "", func(stock *pb.Stock) error {
+		if stock.ReservedCount < req.Quantity {
+			return status.Errorf(codes.FailedPrecondition, "only %d units are reserved, cannot release %d", stock.ReservedCount, req.Quantity)
+		}
+		stock.ReservedCount -= req.Quantity
+		stock.StockCount += req.Quantity
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &pb.ReleaseStockResponse{Stock: stock}, nil
+}
+
+func (c *InventoryCommandController) AdjustStock(ctx context.Context, req *pb.AdjustStockRequest) (*pb.AdjustStockResponse, error) {
+	if req.ProductId == 0 || req.CategoryId == 0 || req.Delta == 0 {
+		return nil, status.Errorf(codes.InvalidArgument, "product id, category id and a non-zero delta are required")
+	}
+
+	stock, err := c.updateStock(ctx, req.ProductId, req.CategoryId, events.InventoryAdjusted, req.Delta, req.Reason, func(stock *pb.Stock) error {
+		if stock.StockCount+req.Delta < 0 {
+			return status.Errorf(codes.FailedPrecondition, "adjustment would make stock negative: %d available, delta %d", stock.StockCount, req.Delta)
+		}
+		stock.StockCount += req.Delta
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &pb.AdjustStockResponse{Stock: stock}, nil
+}
+
+// updateStock applies change to the current inventory row with a lightweight transaction conditioned
+// on the counts it read, retrying when another writer got there first. The transaction cannot share a
+// batch with the outbox, so the event goes through PendingEvents and is never lost once the stock changed.
+func (c *InventoryCommandController) updateStock(ctx context.Context, productId, categoryId int64, event *events.Event[*pb.StockChanged], quantity int32, reason string, change func(stock *pb.Stock) error) (*pb.Stock, error) {
+	key := int64RowKey(productId, categoryId)
+	for attempt := 0; attempt < maxStockUpdateAttempts; attempt++ {
+		if err := c.pending.resume(ctx, pendingInventory, key); err != nil {
+			return nil, status.Errorf(codes.Internal, "failed to finish pending stock events: %v", err)
+		}
+
+		stock, err := getStock(ctx, c.session, productId, categoryId)
+		if err != nil {
+			return nil, err
+		}
+		stockCount, reservedCount := stock.StockCount, stock.ReservedCount
+
+		if err := change(stock); err != nil {
+			return nil, err
+		}
+
+		now := time.Now()
+		stock.LastUpdatedAt = timestamppb.New(now)
+
+		row, err := newOutboxRow(ctx, now, event, &pb.StockChanged{
+			Stock:    stock,
+			Quantity: quantity,
+			Reason:   reason,
+		})
+		if err != nil {
+			return nil, status.Errorf(codes.Internal, "failed to marshal stock change: %v", err)
+		}
+		pending, err := c.pending.record(ctx, pendingInventory, key, row)
+		if err != nil {
+			return nil, status.Errorf(codes.Internal, "failed to update stock: %v", err)
+		}
+
+		applied, err := c.session.Query(
+			`UPDATE products_keyspace_v3.inventory 
+			SET stock_count = ?, reserved_count = ?, last_updated_at = ?, pending_events = pending_events + ? 
+			WHERE product_id = ? AND category_id = ? 
+			IF stock_count = ? AND reserved_count = ?`,
+			stock.StockCount, stock.ReservedCount, now, []gocql.UUID{row.ID}, productId, categoryId, stockCount, reservedCount,
+		).WithContext(ctx).MapScanCAS(map[string]interface{}{})
+		if err != nil {
+			return nil, status.Errorf(codes.Internal, "failed to update stock: %v", err)
+		}
+		if !applied {
+			c.pending.discard(ctx, pending)
+			slog.Warn("Concurrent stock update, retrying", "productID", productId, "attempt", attempt+1)
+			continue
+		}
+
+		// the stock changed and its event is marked on the row, failing the request now would only
+		// invite a second change; the sweep or the next change of the row writes the event instead
+		if err := c.pending.finish(ctx, pending); err != nil {
+			slog.Warn("Stock event left pending", "error", err, "productID", productId, "eventType", event.Name())
+		}
+
+		return stock, nil
+	}
+
+	return nil, status.Errorf(codes.Aborted, "stock is being updated concurrently, try again")
+}
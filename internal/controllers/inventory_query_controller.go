@@ -0,0 +1,58 @@
+package controllers
+
+import (
+	"context"
+	"time"
+
+	"github.com/gocql/gocql"
+	"github.com/yaninyzwitty/cqrs-eccomerce-service/pb"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+type InventoryQueryController struct {
+	pb.UnimplementedInventoryServiceQueryServer
+	session *gocql.Session
+}
+
+func NewInventoryQueryController(session *gocql.Session) *InventoryQueryController {
+	return &InventoryQueryController{session: session}
+}
+
+func (c *InventoryQueryController) GetStock(ctx context.Context, req *pb.GetStockRequest) (*pb.GetStockResponse, error) {
+	if req.ProductId == 0 || req.CategoryId == 0 {
+		return nil, status.Errorf(codes.InvalidArgument, "product id and category id are required")
+	}
+
+	stock, err := getStock(ctx, c.session, req.ProductId, req.CategoryId)
+	if err != nil {
+		return nil, err
+	}
+
+	return &pb.GetStockResponse{Stock: stock}, nil
+}
+
+// getStock reads the inventory row of a product, stock is read from the write table so reservations
+// are never checked against a lagging projection.
+func getStock(ctx context.Context, session *gocql.Session, productId, categoryId int64) (*pb.Stock, error) {
+	stock := pb.Stock{ProductId: productId, CategoryId: categoryId}
+	var createdAt, lastUpdatedAt time.Time
+
+	err := session.Query(
+		`SELECT stock_count, reserved_count, created_at, last_updated_at 
+		FROM products_keyspace_v3.inventory 
+		WHERE product_id = ? AND category_id = ?`,
+		productId, categoryId,
+	).WithContext(ctx).Scan(&stock.StockCount, &stock.ReservedCount, &createdAt, &lastUpdatedAt)
+	if err != nil {
+		if err == gocql.ErrNotFound {
+			return nil, status.Errorf(codes.NotFound, "stock not found")
+		}
+		return nil, status.Errorf(codes.Internal, "failed to fetch stock: %v", err)
+	}
+
+	stock.CreatedAt = timestamppb.New(createdAt)
+	stock.LastUpdatedAt = timestamppb.New(lastUpdatedAt)
+	return &stock, nil
+}
@@ -0,0 +1,195 @@
+package controllers
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	"github.com/gocql/gocql"
+	"github.com/yaninyzwitty/cqrs-eccomerce-service/internal/events"
+	"github.com/yaninyzwitty/cqrs-eccomerce-service/pb"
+	"github.com/yaninyzwitty/cqrs-eccomerce-service/snowflake"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// orderTransitions lists the statuses an order may move to from each status.
+var orderTransitions = map[pb.OrderStatus][]pb.OrderStatus{
+	pb.OrderStatus_ORDER_STATUS_PENDING: {pb.OrderStatus_ORDER_STATUS_PAID, pb.OrderStatus_ORDER_STATUS_CANCELLED},
+	pb.OrderStatus_ORDER_STATUS_PAID:    {pb.OrderStatus_ORDER_STATUS_SHIPPED, pb.OrderStatus_ORDER_STATUS_CANCELLED},
+	pb.OrderStatus_ORDER_STATUS_SHIPPED: {pb.OrderStatus_ORDER_STATUS_DELIVERED},
+}
+
+func canTransitionOrder(from, to pb.OrderStatus) bool {
+	for _, next := range orderTransitions[from] {
+		if next == to {
+			return true
+		}
+	}
+	return false
+}
+
+type OrderCommandController struct {
+	pb.UnsafeOrderServiceCommandServer
+	session *gocql.Session
+	outbox  OutboxNotifier
+	pending *PendingEvents
+}
+
+func NewOrderCommandController(session *gocql.Session, outbox OutboxNotifier) *OrderCommandController {
+	return &OrderCommandController{session: session, outbox: outbox, pending: NewPendingEvents(session, outbox)}
+}
+
+func (c *OrderCommandController) CreateOrder(ctx context.Context, req *pb.CreateOrderRequest) (*pb.CreateOrderResponse, error) {
+	if req.CustomerId == 0 || len(req.Items) == 0 {
+		return nil, status.Errorf(codes.InvalidArgument, "customer id and at least one item are required")
+	}
+
+	// the price is captured now so later product updates do not change the order
+	seen := make(map[int64]bool, len(req.Items))
+	items := make([]*pb.OrderItem, 0, len(req.Items))
+	var total float32
+	for _, item := range req.Items {
+		if item.ProductId == 0 || item.CategoryId == 0 || item.Quantity <= 0 {
+			return nil, status.Errorf(codes.InvalidArgument, "every item needs a product id, category id and a positive quantity")
+		}
+		if seen[item.ProductId] {
+			return nil, status.Errorf(codes.InvalidArgument, "product %d is listed more than once", item.ProductId)
+		}
+		seen[item.ProductId] = true
+
+		var name string
+		var price float32
+		err := c.session.Query(
+			`SELECT name, price FROM products_keyspace_v3.products WHERE category_id = ? AND id = ?`,
+			item.CategoryId, item.ProductId,
+		).WithContext(ctx).Scan(&name, &price)
+		if err != nil {
+			if err == gocql.ErrNotFound {
+				return nil, status.Errorf(codes.NotFound, "product %d not found", item.ProductId)
+			}
+			return nil, status.Errorf(codes.Internal, "failed to fetch product: %v", err)
+		}
+
+		items = append(items, &pb.OrderItem{
+			ProductId:  item.ProductId,
+			CategoryId: item.CategoryId,
+			Name:       name,
+			UnitPrice:  price,
+			Quantity:   item.Quantity,
+		})
+		total += price * float32(item.Quantity)
+	}
+
+	orderId, err := snowflake.GenerateID()
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to generate order id")
+	}
+
+	now := time.Now()
+
+	order := &pb.Order{
+		Id:         int64(orderId),
+		CustomerId: req.CustomerId,
+		Items:      items,
+		Total:      total,
+		Status:     pb.OrderStatus_ORDER_STATUS_PENDING,
+		CreatedAt:  timestamppb.New(now),
+		UpdatedAt:  timestamppb.New(now),
+	}
+
+	batch := c.session.NewBatch(gocql.LoggedBatch).WithContext(ctx)
+
+	batch.Query(
+		`INSERT INTO products_keyspace_v3.orders 
+		(id, customer_id, status, total, created_at, updated_at) 
+		VALUES (?, ?, ?, ?, ?, ?)`,
+		orderId, req.CustomerId, order.Status.String(), total, now, now,
+	)
+	for _, item := range items {
+		batch.Query(
+			`INSERT INTO products_keyspace_v3.order_items 
+			(order_id, product_id, category_id, name, unit_price, quantity) 
+			VALUES (?, ?, ?, ?, ?, ?)`,
+			orderId, item.ProductId, item.CategoryId, item.Name, item.UnitPrice, item.Quantity,
+		)
+	}
+
+	if err := addOutboxMessage(batch, now, events.OrderCreated, order); err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to marshal order: %v", err)
+	}
+
+	if err := c.session.ExecuteBatch(batch); err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to create order: %v", err)
+	}
+	c.outbox.Notify()
+
+	return &pb.CreateOrderResponse{Order: order}, nil
+}
+
+// UpdateOrderStatus moves an order along its state machine. The status is written with a lightweight
+// transaction conditioned on the status the transition was checked against, so of two racing
+// transitions only one applies and the other fails with Aborted.
+func (c *OrderCommandController) UpdateOrderStatus(ctx context.Context, req *pb.UpdateOrderStatusRequest) (*pb.UpdateOrderStatusResponse, error) {
+	if req.Id == 0 || req.Status == pb.OrderStatus_ORDER_STATUS_UNSPECIFIED {
+		return nil, status.Errorf(codes.InvalidArgument, "id and status are required")
+	}
+
+	key := int64RowKey(req.Id)
+	if err := c.pending.resume(ctx, pendingOrders, key); err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to finish pending order events: %v", err)
+	}
+
+	order, err := getOrder(ctx, c.session, req.Id)
+	if err != nil {
+		return nil, err
+	}
+
+	if !canTransitionOrder(order.Status, req.Status) {
+		return nil, status.Errorf(codes.FailedPrecondition, "order cannot move from %s to %s", order.Status, req.Status)
+	}
+
+	now := time.Now()
+	previousStatus := order.Status
+	order.Status = req.Status
+	order.UpdatedAt = timestamppb.New(now)
+
+	row, err := newOutboxRow(ctx, now, events.OrderStatusChanged, &pb.OrderStatusChanged{
+		OrderId:        order.Id,
+		CustomerId:     order.CustomerId,
+		PreviousStatus: previousStatus,
+		Status:         req.Status,
+		ChangedAt:      timestamppb.New(now),
+	})
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to marshal order status change: %v", err)
+	}
+	pending, err := c.pending.record(ctx, pendingOrders, key, row)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to update order status: %v", err)
+	}
+
+	applied, err := c.session.Query(
+		`UPDATE products_keyspace_v3.orders 
+		SET status = ?, updated_at = ?, pending_events = pending_events + ? 
+		WHERE id = ? 
+		IF status = ?`,
+		req.Status.String(), now, []gocql.UUID{row.ID}, req.Id, previousStatus.String(),
+	).WithContext(ctx).MapScanCAS(map[string]interface{}{})
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to update order status: %v", err)
+	}
+	if !applied {
+		c.pending.discard(ctx, pending)
+		return nil, status.Errorf(codes.Aborted, "order status changed concurrently, try again")
+	}
+
+	// the status changed and its event is marked on the order, the sweep or the next change of the
+	// order writes the event if this fails
+	if err := c.pending.finish(ctx, pending); err != nil {
+		slog.Warn("Order event left pending", "error", err, "orderID", req.Id)
+	}
+
+	return &pb.UpdateOrderStatusResponse{Order: order}, nil
+}
@@ -0,0 +1,191 @@
+package controllers
+
+import (
+	"context"
+	"time"
+
+	"github.com/gocql/gocql"
+	"github.com/yaninyzwitty/cqrs-eccomerce-service/pb"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+type OrderQueryController struct {
+	pb.UnimplementedOrderServiceQueryServer
+	session *gocql.Session
+}
+
+func NewOrderQueryController(session *gocql.Session) *OrderQueryController {
+	return &OrderQueryController{session: session}
+}
+
+func (c *OrderQueryController) GetOrder(ctx context.Context, req *pb.GetOrderRequest) (*pb.GetOrderResponse, error) {
+	if req.Id == 0 {
+		return nil, status.Errorf(codes.InvalidArgument, "id is required")
+	}
+
+	order, err := getOrder(ctx, c.session, req.Id)
+	if err != nil {
+		return nil, err
+	}
+
+	return &pb.GetOrderResponse{Order: order}, nil
+}
+
+func (c *OrderQueryController) GetOrders(ctx context.Context, req *pb.GetOrdersRequest) (*pb.GetOrdersResponse, error) {
+	if req.PageSize <= 0 {
+		return nil, status.Errorf(codes.InvalidArgument, "page size is required")
+	}
+
+	query := c.session.Query(`
+		SELECT id, customer_id, status, total, created_at, updated_at 
+		FROM products_keyspace_v3.orders`,
+	).WithContext(ctx).PageSize(int(req.PageSize)).PageState(req.PagingState)
+
+	iter := query.Iter()
+	defer iter.Close()
+
+	var orders []*pb.Order
+	var (
+		id          int64
+		customerId  int64
+		orderStatus string
+		total       float32
+		createdAt   time.Time
+		updatedAt   time.Time
+	)
+
+	for iter.Scan(&id, &customerId, &orderStatus, &total, &createdAt, &updatedAt) {
+		orders = append(orders, &pb.Order{
+			Id:         id,
+			CustomerId: customerId,
+			Status:     pb.OrderStatus(pb.OrderStatus_value[orderStatus]),
+			Total:      total,
+			CreatedAt:  timestamppb.New(createdAt),
+			UpdatedAt:  timestamppb.New(updatedAt),
+		})
+	}
+
+	nextPageState := iter.PageState()
+
+	if err := iter.Close(); err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to list orders: %v", err)
+	}
+
+	for _, order := range orders {
+		items, err := getOrderItems(ctx, c.session, order.Id)
+		if err != nil {
+			return nil, err
+		}
+		order.Items = items
+	}
+
+	return &pb.GetOrdersResponse{
+		Orders:      orders,
+		PagingState: nextPageState,
+	}, nil
+}
+
+func (c *OrderQueryController) GetOrderHistory(ctx context.Context, req *pb.GetOrderHistoryRequest) (*pb.GetOrderHistoryResponse, error) {
+	if req.CustomerId == 0 || req.PageSize <= 0 {
+		return nil, status.Errorf(codes.InvalidArgument, "customer id and page size are required")
+	}
+
+	query := c.session.Query(`
+		SELECT order_id, status, total, item_count, created_at, updated_at 
+		FROM products_keyspace_v3.orders_by_customer_view 
+		WHERE customer_id = ?`,
+		req.CustomerId,
+	).WithContext(ctx).PageSize(int(req.PageSize)).PageState(req.PagingState)
+
+	iter := query.Iter()
+	defer iter.Close()
+
+	var orders []*pb.OrderSummary
+	var (
+		orderId     int64
+		orderStatus string
+		total       float32
+		itemCount   int32
+		createdAt   time.Time
+		updatedAt   time.Time
+	)
+
+	for iter.Scan(&orderId, &orderStatus, &total, &itemCount, &createdAt, &updatedAt) {
+		orders = append(orders, &pb.OrderSummary{
+			OrderId:   orderId,
+			Status:    pb.OrderStatus(pb.OrderStatus_value[orderStatus]),
+			Total:     total,
+			ItemCount: itemCount,
+			CreatedAt: timestamppb.New(createdAt),
+			UpdatedAt: timestamppb.New(updatedAt),
+		})
+	}
+
+	nextPageState := iter.PageState()
+
+	if err := iter.Close(); err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to fetch order history: %v", err)
+	}
+
+	return &pb.GetOrderHistoryResponse{
+		Orders:      orders,
+		PagingState: nextPageState,
+	}, nil
+}
+
+// getOrder reads an order together with its line items.
+func getOrder(ctx context.Context, session *gocql.Session, id int64) (*pb.Order, error) {
+	order := pb.Order{Id: id}
+	var orderStatus string
+	var createdAt, updatedAt time.Time
+
+	err := session.Query(
+		`SELECT customer_id, status, total, created_at, updated_at 
+		FROM products_keyspace_v3.orders 
+		WHERE id = ?`,
+		id,
+	).WithContext(ctx).Scan(&order.CustomerId, &orderStatus, &order.Total, &createdAt, &updatedAt)
+	if err != nil {
+		if err == gocql.ErrNotFound {
+			return nil, status.Errorf(codes.NotFound, "order not found")
+		}
+		return nil, status.Errorf(codes.Internal, "failed to fetch order: %v", err)
+	}
+
+	order.Status = pb.OrderStatus(pb.OrderStatus_value[orderStatus])
+	order.CreatedAt = timestamppb.New(createdAt)
+	order.UpdatedAt = timestamppb.New(updatedAt)
+
+	items, err := getOrderItems(ctx, session, id)
+	if err != nil {
+		return nil, err
+	}
+	order.Items = items
+
+	return &order, nil
+}
+
+func getOrderItems(ctx context.Context, session *gocql.Session, orderId int64) ([]*pb.OrderItem, error) {
+	iter := session.Query(
+		`SELECT product_id, category_id, name, unit_price, quantity 
+		FROM products_keyspace_v3.order_items 
+		WHERE order_id = ?`,
+		orderId,
+	).WithContext(ctx).Iter()
+
+	var items []*pb.OrderItem
+	for {
+		var item pb.OrderItem
+		if !iter.Scan(&item.ProductId, &item.CategoryId, &item.Name, &item.UnitPrice, &item.Quantity) {
+			break
+		}
+		items = append(items, &item)
+	}
+
+	if err := iter.Close(); err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to fetch order items: %v", err)
+	}
+	return items, nil
+}
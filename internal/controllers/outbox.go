@@ -0,0 +1,84 @@
+package controllers
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/gocql/gocql"
+	"github.com/yaninyzwitty/cqrs-eccomerce-service/internal/events"
+	"github.com/yaninyzwitty/cqrs-eccomerce-service/internal/repository"
+	"google.golang.org/protobuf/proto"
+)
+
+// OutboxNotifier is told after outbox rows were written, so the relay publishes them right away
+// instead of waiting for its next poll.
+type OutboxNotifier interface {
+	Notify()
+}
+
+// outboxRow is an event on its way to the outbox.
+type outboxRow struct {
+	ID            gocql.UUID
+	Bucket        string
+	EventType     string
+	Payload       string
+	CorrelationID string
+	CausationID   string
+	// DeliverAt holds the event back until then, zero delivers it right away.
+	DeliverAt time.Time
+}
+
+// newOutboxRow encodes message as the payload of event, the payload type is checked against the
+// event at compile time. The trace of the request on ctx is kept with the row for the event envelope.
+func newOutboxRow[T proto.Message](ctx context.Context, now time.Time, event *events.Event[T], message T) (outboxRow, error) {
+	payload, err := event.Marshal(message)
+	if err != nil {
+		return outboxRow{}, fmt.Errorf("failed to marshal %s payload: %w", event.Name(), err)
+	}
+
+	trace := events.TraceFromContext(ctx)
+	return outboxRow{
+		ID:            gocql.TimeUUID(),
+		Bucket:        repository.OutboxBucket(now),
+		EventType:     event.Name(),
+		Payload:       string(payload),
+		CorrelationID: trace.CorrelationID,
+		CausationID:   trace.CausationID,
+	}, nil
+}
+
+// add queues the row in batch. The bucket is indexed alongside so the relay keeps sweeping it
+// after the date rolls over, a scheduled row stays in its bucket until the relay hands it over.
+func (r outboxRow) add(batch *gocql.Batch) {
+	var deliverAt interface{} = gocql.UnsetValue
+	if !r.DeliverAt.IsZero() {
+		deliverAt = r.DeliverAt
+	}
+
+	batch.Query(
+		`INSERT INTO products_keyspace_v3.outbox 
+		(id, bucket, payload, event_type, correlation_id, causation_id, deliver_at) 
+		VALUES (?, ?, ?, ?, ?, ?, ?)`,
+		r.ID, r.Bucket, r.Payload, r.EventType, r.CorrelationID, r.CausationID, deliverAt,
+	)
+	indexOutboxBucket(batch, r.Bucket)
+}
+
+// addOutboxMessage queues the outbox row for an event in the same batch as the state change,
+// so the event is only published if the write itself succeeded.
+func addOutboxMessage[T proto.Message](batch *gocql.Batch, now time.Time, event *events.Event[T], message T) error {
+	row, err := newOutboxRow(batch.Context(), now, event, message)
+	if err != nil {
+		return err
+	}
+	row.add(batch)
+	return nil
+}
+
+func indexOutboxBucket(batch *gocql.Batch, bucket string) {
+	batch.Query(
+		`INSERT INTO products_keyspace_v3.outbox_buckets (shard, bucket) VALUES (?, ?)`,
+		repository.OutboxBucketShard, bucket,
+	)
+}
@@ -0,0 +1,238 @@
+package controllers
+
+import (
+	"context"
+	"log/slog"
+	"sort"
+	"time"
+
+	"github.com/gocql/gocql"
+	"github.com/yaninyzwitty/cqrs-eccomerce-service/internal/repository"
+	"github.com/yaninyzwitty/cqrs-eccomerce-service/pb"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// defaultAdminListLimit applies when a list request sets no limit.
+const defaultAdminListLimit = 100
+
+// OutboxAdminController lets operators inspect the outbox and repair rows the relay is stuck on.
+type OutboxAdminController struct {
+	pb.UnsafeOutboxAdminServiceServer
+	repo   repository.OutboxAdminRepository
+	outbox OutboxNotifier
+}
+
+func NewOutboxAdminController(repo repository.OutboxAdminRepository, outbox OutboxNotifier) *OutboxAdminController {
+	return &OutboxAdminController{repo: repo, outbox: outbox}
+}
+
+func (c *OutboxAdminController) ListBuckets(ctx context.Context, req *pb.ListBucketsRequest) (*pb.ListBucketsResponse, error) {
+	pendingBuckets, err := c.repo.FetchBuckets(ctx)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to fetch outbox buckets: %v", err)
+	}
+	deadLetterBuckets, err := c.repo.FetchDeadLetterBuckets(ctx)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to fetch dead letter buckets: %v", err)
+	}
+
+	summaries := make(map[string]*pb.BucketSummary)
+	summary := func(bucket string) *pb.BucketSummary {
+		if _, ok := summaries[bucket]; !ok {
+			summaries[bucket] = &pb.BucketSummary{Bucket: bucket}
+		}
+		return summaries[bucket]
+	}
+
+	for _, bucket := range pendingBuckets {
+		messages, err := c.repo.FetchMessages(ctx, bucket)
+		if err != nil {
+			return nil, status.Errorf(codes.Internal, "failed to fetch outbox rows: %v", err)
+		}
+		summary(bucket).Pending = int32(len(messages))
+	}
+	for _, bucket := range deadLetterBuckets {
+		messages, err := c.repo.FetchDeadLetters(ctx, bucket)
+		if err != nil {
+			return nil, status.Errorf(codes.Internal, "failed to fetch dead letters: %v", err)
+		}
+		summary(bucket).DeadLetters = int32(len(messages))
+	}
+
+	buckets := make([]*pb.BucketSummary, 0, len(summaries))
+	for _, s := range summaries {
+		buckets = append(buckets, s)
+	}
+	sort.Slice(buckets, func(i, j int) bool { return buckets[i].Bucket < buckets[j].Bucket })
+
+	return &pb.ListBucketsResponse{Buckets: buckets}, nil
+}
+
+func (c *OutboxAdminController) ListPending(ctx context.Context, req *pb.ListRowsRequest) (*pb.ListRowsResponse, error) {
+	if req.Bucket == "" {
+		return nil, status.Errorf(codes.InvalidArgument, "bucket is required")
+	}
+
+	messages, err := c.repo.FetchMessages(ctx, req.Bucket)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to fetch outbox rows: %v", err)
+	}
+	return listRows(messages, req.Limit), nil
+}
+
+func (c *OutboxAdminController) ListDeadLetters(ctx context.Context, req *pb.ListRowsRequest) (*pb.ListRowsResponse, error) {
+	if req.Bucket == "" {
+		return nil, status.Errorf(codes.InvalidArgument, "bucket is required")
+	}
+
+	messages, err := c.repo.FetchDeadLetters(ctx, req.Bucket)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to fetch dead letters: %v", err)
+	}
+	return listRows(messages, req.Limit), nil
+}
+
+func (c *OutboxAdminController) Republish(ctx context.Context, req *pb.RepublishRequest) (*pb.RepublishResponse, error) {
+	messages, err := c.selectRows(ctx, req.Rows)
+	if err != nil {
+		return nil, err
+	}
+
+	if req.Rows.Table == pb.OutboxTable_OUTBOX_TABLE_DEAD_LETTER {
+		err = c.repo.RequeueDeadLetters(ctx, messages)
+	} else {
+		err = c.repo.ResetMessages(ctx, messages)
+	}
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to republish rows: %v", err)
+	}
+	c.outbox.Notify()
+
+	slog.Info("Outbox rows republished", "bucket", req.Rows.Bucket, "table", req.Rows.Table, "count", len(messages))
+	return &pb.RepublishResponse{Republished: int32(len(messages))}, nil
+}
+
+func (c *OutboxAdminController) Purge(ctx context.Context, req *pb.PurgeRequest) (*pb.PurgeResponse, error) {
+	messages, err := c.selectRows(ctx, req.Rows)
+	if err != nil {
+		return nil, err
+	}
+
+	if req.Rows.Table == pb.OutboxTable_OUTBOX_TABLE_DEAD_LETTER {
+		err = c.repo.DeleteDeadLetters(ctx, messages)
+	} else {
+		err = c.repo.DeleteMessages(ctx, messages)
+	}
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to purge rows: %v", err)
+	}
+
+	slog.Warn("Outbox rows purged", "bucket", req.Rows.Bucket, "table", req.Rows.Table, "count", len(messages))
+	return &pb.PurgeResponse{Purged: int32(len(messages))}, nil
+}
+
+// selectRows resolves a selector to the rows it picks, exactly one of ids, a time range or all
+// must be given so a forgotten filter never touches a whole bucket.
+func (c *OutboxAdminController) selectRows(ctx context.Context, selector *pb.RowSelector) ([]repository.OutboxMessage, error) {
+	if selector == nil || selector.Bucket == "" {
+		return nil, status.Errorf(codes.InvalidArgument, "bucket is required")
+	}
+
+	byIds := len(selector.Ids) > 0
+	byRange := selector.From != nil || selector.To != nil
+	modes := 0
+	for _, set := range []bool{byIds, byRange, selector.All} {
+		if set {
+			modes++
+		}
+	}
+	if modes != 1 {
+		return nil, status.Errorf(codes.InvalidArgument, "select rows by ids, by time range or all of them")
+	}
+
+	ids := make(map[gocql.UUID]bool, len(selector.Ids))
+	for _, id := range selector.Ids {
+		uuid, err := gocql.ParseUUID(id)
+		if err != nil {
+			return nil, status.Errorf(codes.InvalidArgument, "invalid row id %q: %v", id, err)
+		}
+		ids[uuid] = true
+	}
+
+	var messages []repository.OutboxMessage
+	var err error
+	if selector.Table == pb.OutboxTable_OUTBOX_TABLE_DEAD_LETTER {
+		messages, err = c.repo.FetchDeadLetters(ctx, selector.Bucket)
+	} else {
+		messages, err = c.repo.FetchMessages(ctx, selector.Bucket)
+	}
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to fetch rows: %v", err)
+	}
+
+	from, to := time.Time{}, time.Now()
+	if selector.From != nil {
+		from = selector.From.AsTime()
+	}
+	if selector.To != nil {
+		to = selector.To.AsTime()
+	}
+
+	var selected []repository.OutboxMessage
+	for _, message := range messages {
+		switch {
+		case byIds && !ids[message.Id]:
+			continue
+		case byRange && (message.Id.Time().Before(from) || message.Id.Time().After(to)):
+			continue
+		}
+		selected = append(selected, message)
+	}
+
+	if len(selected) == 0 {
+		return nil, status.Errorf(codes.NotFound, "no rows match in bucket %s", selector.Bucket)
+	}
+	return selected, nil
+}
+
+func listRows(messages []repository.OutboxMessage, limit int32) *pb.ListRowsResponse {
+	if limit <= 0 {
+		limit = defaultAdminListLimit
+	}
+
+	response := &pb.ListRowsResponse{}
+	for i, message := range messages {
+		if i == int(limit) {
+			response.Truncated = true
+			break
+		}
+		response.Rows = append(response.Rows, toOutboxRow(message))
+	}
+	return response
+}
+
+func toOutboxRow(message repository.OutboxMessage) *pb.OutboxRow {
+	row := &pb.OutboxRow{
+		Id:            message.Id.String(),
+		Bucket:        message.Bucket,
+		EventType:     message.EventType,
+		Payload:       message.Payload,
+		Attempts:      int32(message.Attempts),
+		LastError:     message.LastError,
+		CreatedAt:     timestamppb.New(message.Id.Time()),
+		CorrelationId: message.CorrelationID,
+		CausationId:   message.CausationID,
+	}
+	if !message.NextAttemptAt.IsZero() {
+		row.NextAttemptAt = timestamppb.New(message.NextAttemptAt)
+	}
+	if !message.DeadLetteredAt.IsZero() {
+		row.DeadLetteredAt = timestamppb.New(message.DeadLetteredAt)
+	}
+	if !message.DeliverAt.IsZero() {
+		row.DeliverAt = timestamppb.New(message.DeliverAt)
+	}
+	return row
+}
@@ -0,0 +1,330 @@
+package controllers
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"slices"
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/gocql/gocql"
+)
+
+// pendingEventsShard keeps every pending event in one partition, only events of requests that
+// failed halfway stay there for long.
+const pendingEventsShard = 0
+
+// pendingEventGrace is how long Sweep leaves a pending event to the request that recorded it, it
+// outlasts any request so a transaction still in flight is never mistaken for one that failed.
+const pendingEventGrace = time.Minute
+
+// pending event sources, the tables changed by lightweight transactions
+const (
+	pendingInventory     = "inventory"
+	pendingOrders        = "orders"
+	pendingCarts         = "carts"
+	pendingProducts      = "products"
+	pendingProductEvents = "product_events"
+)
+
+// pendingSource is a table whose rows carry a pending_events set with the ids of the events their
+// transactions recorded that have not reached the outbox yet.
+type pendingSource struct {
+	table string
+	// where matches the marked row, bound to the values returned by bind
+	where string
+	bind  func(key rowKey) ([]interface{}, error)
+	// apply adds the writes derived from the event to the batch finishing it, for sources whose
+	// transaction only records the event
+	apply func(batch *gocql.Batch, row outboxRow) error
+}
+
+var pendingSources = map[string]pendingSource{
+	pendingInventory: {table: "inventory", where: "product_id = ? AND category_id = ?", bind: rowKey.int64s},
+	pendingOrders:    {table: "orders", where: "id = ?", bind: rowKey.int64s},
+	pendingCarts:     {table: "carts", where: "cart_id = ?", bind: rowKey.texts},
+	pendingProducts:  {table: "products", where: "category_id = ? AND id = ?", bind: rowKey.int64s},
+	pendingProductEvents: {
+		table: "event_store",
+		where: "aggregate_type = ? AND aggregate_id = ?",
+		bind:  rowKey.aggregate,
+		apply: applyProductEvent,
+	},
+}
+
+// rowKey is the primary key of a marked row, kept as text so the key of any table fits in
+// pending_outbox.
+type rowKey []string
+
+func int64RowKey(values ...int64) rowKey {
+	key := make(rowKey, len(values))
+	for i, value := range values {
+		key[i] = strconv.FormatInt(value, 10)
+	}
+	return key
+}
+
+func (k rowKey) int64s() ([]interface{}, error) {
+	values := make([]interface{}, len(k))
+	for i, part := range k {
+		value, err := strconv.ParseInt(part, 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("invalid row key %v: %w", k, err)
+		}
+		values[i] = value
+	}
+	return values, nil
+}
+
+func (k rowKey) texts() ([]interface{}, error) {
+	values := make([]interface{}, len(k))
+	for i, part := range k {
+		values[i] = part
+	}
+	return values, nil
+}
+
+// aggregate binds the key of an event store partition, the aggregate type and its id.
+func (k rowKey) aggregate() ([]interface{}, error) {
+	if len(k) != 2 {
+		return nil, fmt.Errorf("invalid aggregate key %v", k)
+	}
+	id, err := strconv.ParseInt(k[1], 10, 64)
+	if err != nil {
+		return nil, fmt.Errorf("invalid aggregate key %v: %w", k, err)
+	}
+	return []interface{}{k[0], id}, nil
+}
+
+// pendingEvent is an outbox row waiting for the transaction that marks its source row.
+type pendingEvent struct {
+	source string
+	key    rowKey
+	row    outboxRow
+}
+
+// PendingEvents carries the events of lightweight transactions to the outbox. A conditional write
+// cannot share a batch with the outbox, so its event is recorded in pending_outbox first, the
+// transaction adds the event id to the pending_events of its row, and one batch then writes the
+// outbox row, clears the mark and drops the record. A mark left behind by a request that failed in
+// between is finished before the row changes again or by Sweep, a record without a mark belongs
+// to a transaction that did not apply.
+type PendingEvents struct {
+	session *gocql.Session
+	outbox  OutboxNotifier
+}
+
+func NewPendingEvents(session *gocql.Session, outbox OutboxNotifier) *PendingEvents {
+	return &PendingEvents{session: session, outbox: outbox}
+}
+
+// record stores row ahead of the transaction of the source row, which must add row.ID to the
+// row's pending_events.
+func (p *PendingEvents) record(ctx context.Context, source string, key rowKey, row outboxRow) (pendingEvent, error) {
+	event, err := json.Marshal(row)
+	if err != nil {
+		return pendingEvent{}, fmt.Errorf("failed to marshal pending event: %w", err)
+	}
+
+	err = p.session.Query(
+		`INSERT INTO products_keyspace_v3.pending_outbox
+		(shard, id, source, row_key, event)
+		VALUES (?, ?, ?, ?, ?)`,
+		pendingEventsShard, row.ID, source, []string(key), string(event),
+	).WithContext(ctx).Exec()
+	if err != nil {
+		return pendingEvent{}, fmt.Errorf("failed to record pending event: %w", err)
+	}
+	return pendingEvent{source: source, key: key, row: row}, nil
+}
+
+// finish writes the outbox row of an applied transaction along with the writes derived from it,
+// clears its mark and drops its record in one batch.
+func (p *PendingEvents) finish(ctx context.Context, event pendingEvent) error {
+	unmark, values, err := unmarkQuery(event)
+	if err != nil {
+		return err
+	}
+
+	batch := p.session.NewBatch(gocql.LoggedBatch).WithContext(ctx)
+	if apply := pendingSources[event.source].apply; apply != nil {
+		if err := apply(batch, event.row); err != nil {
+			return err
+		}
+	}
+	event.row.add(batch)
+	batch.Query(unmark, values...)
+	batch.Query(
+		`DELETE FROM products_keyspace_v3.pending_outbox WHERE shard = ? AND id = ?`,
+		pendingEventsShard, event.row.ID,
+	)
+	if err := p.session.ExecuteBatch(batch); err != nil {
+		return fmt.Errorf("failed to finish pending event: %w", err)
+	}
+	p.outbox.Notify()
+	return nil
+}
+
+// discard drops the record of a transaction that did not apply, a record left behind is
+// dropped by Sweep.
+func (p *PendingEvents) discard(ctx context.Context, event pendingEvent) {
+	err := p.session.Query(
+		`DELETE FROM products_keyspace_v3.pending_outbox WHERE shard = ? AND id = ?`,
+		pendingEventsShard, event.row.ID,
+	).WithContext(ctx).Exec()
+	if err != nil {
+		slog.Warn("Failed to discard pending event", "error", err, "eventID", event.row.ID)
+	}
+}
+
+// resume finishes the events still marked on a row, oldest first, so they reach the outbox ahead
+// of the events of the next change.
+func (p *PendingEvents) resume(ctx context.Context, source string, key rowKey) error {
+	ids, err := p.marks(ctx, source, key)
+	if err != nil {
+		return err
+	}
+	sort.Slice(ids, func(i, j int) bool { return ids[i].Time().Before(ids[j].Time()) })
+
+	for _, id := range ids {
+		event, found, err := p.load(ctx, id)
+		if err != nil {
+			return err
+		}
+		if !found {
+			// a mark without a record would be resumed on every change, clear it
+			event = pendingEvent{source: source, key: key, row: outboxRow{ID: id}}
+			if err := p.clear(ctx, event); err != nil {
+				return err
+			}
+			continue
+		}
+		slog.Warn("Finishing event left pending by an earlier request", "source", source, "key", key, "eventID", id)
+		if err := p.finish(ctx, event); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Sweep finishes the pending events of applied transactions whose requests failed before writing
+// the outbox and drops the records of transactions that did not apply. It runs in the relay so
+// the events are published by the same pass.
+func (p *PendingEvents) Sweep(ctx context.Context) error {
+	iter := p.session.Query(
+		`SELECT id FROM products_keyspace_v3.pending_outbox WHERE shard = ?`,
+		pendingEventsShard,
+	).WithContext(ctx).Iter()
+
+	var ids []gocql.UUID
+	var id gocql.UUID
+	cutoff := time.Now().Add(-pendingEventGrace)
+	for iter.Scan(&id) {
+		if id.Time().Before(cutoff) {
+			ids = append(ids, id)
+		}
+	}
+	if err := iter.Close(); err != nil {
+		return fmt.Errorf("failed to list pending events: %w", err)
+	}
+
+	for _, id := range ids {
+		event, found, err := p.load(ctx, id)
+		if err != nil {
+			return err
+		}
+		if !found {
+			continue
+		}
+
+		marks, err := p.marks(ctx, event.source, event.key)
+		if err != nil {
+			return err
+		}
+		if !slices.Contains(marks, id) {
+			p.discard(ctx, event)
+			continue
+		}
+
+		slog.Warn("Finishing event left pending by a failed request", "source", event.source, "key", event.key, "eventID", id)
+		if err := p.finish(ctx, event); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// marks reads the ids of the events pending on a row.
+func (p *PendingEvents) marks(ctx context.Context, source string, key rowKey) ([]gocql.UUID, error) {
+	s, ok := pendingSources[source]
+	if !ok {
+		return nil, fmt.Errorf("unknown pending event source %q", source)
+	}
+	values, err := s.bind(key)
+	if err != nil {
+		return nil, err
+	}
+
+	var ids []gocql.UUID
+	err = p.session.Query(
+		`SELECT pending_events FROM products_keyspace_v3.`+s.table+` WHERE `+s.where,
+		values...,
+	).WithContext(ctx).Scan(&ids)
+	if err != nil && err != gocql.ErrNotFound {
+		return nil, fmt.Errorf("failed to read pending events of %s %v: %w", source, key, err)
+	}
+	return ids, nil
+}
+
+// load reads the record of a pending event.
+func (p *PendingEvents) load(ctx context.Context, id gocql.UUID) (pendingEvent, bool, error) {
+	var event pendingEvent
+	var key []string
+	var row string
+	err := p.session.Query(
+		`SELECT source, row_key, event FROM products_keyspace_v3.pending_outbox WHERE shard = ? AND id = ?`,
+		pendingEventsShard, id,
+	).WithContext(ctx).Scan(&event.source, &key, &row)
+	if err != nil {
+		if err == gocql.ErrNotFound {
+			return pendingEvent{}, false, nil
+		}
+		return pendingEvent{}, false, fmt.Errorf("failed to read pending event %s: %w", id, err)
+	}
+
+	event.key = key
+	if err := json.Unmarshal([]byte(row), &event.row); err != nil {
+		return pendingEvent{}, false, fmt.Errorf("failed to unmarshal pending event %s: %w", id, err)
+	}
+	return event, true, nil
+}
+
+// clear removes the mark of an event from its row.
+func (p *PendingEvents) clear(ctx context.Context, event pendingEvent) error {
+	unmark, values, err := unmarkQuery(event)
+	if err != nil {
+		return err
+	}
+	if err := p.session.Query(unmark, values...).WithContext(ctx).Exec(); err != nil {
+		return fmt.Errorf("failed to clear pending event %s: %w", event.row.ID, err)
+	}
+	return nil
+}
+
+// unmarkQuery builds the statement removing the mark of an event from its row.
+func unmarkQuery(event pendingEvent) (string, []interface{}, error) {
+	source, ok := pendingSources[event.source]
+	if !ok {
+		return "", nil, fmt.Errorf("unknown pending event source %q", event.source)
+	}
+	key, err := source.bind(event.key)
+	if err != nil {
+		return "", nil, err
+	}
+
+	stmt := `UPDATE products_keyspace_v3.` + source.table + ` SET pending_events = pending_events - ? WHERE ` + source.where
+	return stmt, append([]interface{}{[]gocql.UUID{event.row.ID}}, key...), nil
+}
@@ -2,24 +2,44 @@ package controllers
 
 import (
 	"context"
-	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"strings"
 	"time"
 
 	"github.com/gocql/gocql"
+	"github.com/yaninyzwitty/cqrs-eccomerce-service/internal/events"
+	"github.com/yaninyzwitty/cqrs-eccomerce-service/internal/eventstore"
 	"github.com/yaninyzwitty/cqrs-eccomerce-service/pb"
 	"github.com/yaninyzwitty/cqrs-eccomerce-service/snowflake"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
 	"google.golang.org/protobuf/types/known/timestamppb"
 )
 
+// categoryDeletePageSize bounds the products of a category deleted per page.
+const categoryDeletePageSize = 100
+
+// ProductCommandController writes products and categories. When eventStore is set, product changes are
+// committed by appending them to the event store with an expected version check, the products row is
+// written from the event afterwards and acts as a snapshot for the other services.
 type ProductCommandController struct {
 	pb.UnsafeProductServiceCommandServer
-	session *gocql.Session
+	session    *gocql.Session
+	outbox     OutboxNotifier
+	pending    *PendingEvents
+	eventStore eventstore.EventStore
 }
 
-func NewCommandProductCommandController(session *gocql.Session) *ProductCommandController {
-	return &ProductCommandController{session: session}
+func NewCommandProductCommandController(session *gocql.Session, outbox OutboxNotifier, eventStore eventstore.EventStore) *ProductCommandController {
+	return &ProductCommandController{
+		session:    session,
+		outbox:     outbox,
+		pending:    NewPendingEvents(session, outbox),
+		eventStore: eventStore,
+	}
 }
 
 func (c *ProductCommandController) CreateCategory(ctx context.Context, req *pb.CreateCategoryRequest) (*pb.CreateCategoryResponse, error) {
@@ -33,9 +53,6 @@ func (c *ProductCommandController) CreateCategory(ctx context.Context, req *pb.C
 	}
 
 	now := time.Now()
-	outboxID := gocql.TimeUUID()
-	bucket := now.Format("2006-01-02")
-	eventType := "category.created"
 
 	category := &pb.Category{
 		Id:          int64(categoryId),
@@ -43,11 +60,6 @@ func (c *ProductCommandController) CreateCategory(ctx context.Context, req *pb.C
 		Description: req.Description,
 		CreatedAt:   timestamppb.New(now),
 	}
-	payload, err := json.Marshal(category)
-	if err != nil {
-		return nil, status.Errorf(codes.Internal, "failed to marshal category: %v", err)
-	}
-
 	batch := c.session.NewBatch(gocql.LoggedBatch).WithContext(ctx)
 
 	batch.Query(
@@ -56,15 +68,13 @@ func (c *ProductCommandController) CreateCategory(ctx context.Context, req *pb.C
 		VALUES (?, ?, ?, ?)`,
 		categoryId, req.Name, req.Description, now,
 	)
-	batch.Query(
-		`INSERT INTO products_keyspace_v3.outbox 
-		(id, bucket, payload, event_type) 
-		VALUES (?, ?, ?, ?)`,
-		outboxID, bucket, payload, eventType,
-	)
+	if err := addOutboxMessage(batch, now, events.CategoryCreated, category); err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to marshal category: %v", err)
+	}
 	if err := c.session.ExecuteBatch(batch); err != nil {
 		return nil, status.Errorf(codes.Internal, "failed to create product: %v", err)
 	}
+	c.outbox.Notify()
 
 	return &pb.CreateCategoryResponse{
 		Id:          int64(categoryId),
@@ -85,9 +95,6 @@ func (c *ProductCommandController) CreateProduct(ctx context.Context, req *pb.Cr
 	}
 
 	now := time.Now()
-	outboxID := gocql.TimeUUID()
-	bucket := now.Format("2006-01-02")
-	eventType := "product.created"
 
 	product := &pb.Product{
 		Id:          int64(productId),
@@ -100,30 +107,468 @@ func (c *ProductCommandController) CreateProduct(ctx context.Context, req *pb.Cr
 		UpdatedAt:   timestamppb.New(now),
 	}
 
-	payload, err := json.Marshal(product)
+	row, err := newOutboxRow(ctx, now, events.ProductCreated, product)
 	if err != nil {
 		return nil, status.Errorf(codes.Internal, "failed to marshal product: %v", err)
 	}
+	if err := c.commitProductEvent(ctx, product.Id, 0, row); err != nil {
+		return nil, err
+	}
+
+	return &pb.CreateProductResponse{Product: product}, nil
+}
+
+func (c *ProductCommandController) UpdateProduct(ctx context.Context, req *pb.UpdateProductRequest) (*pb.UpdateProductResponse, error) {
+	if req.CategoryId == 0 || req.ProductId == 0 {
+		return nil, status.Errorf(codes.InvalidArgument, "category id and product id are required")
+	}
+	if len(req.GetUpdateMask().GetPaths()) == 0 {
+		return nil, status.Errorf(codes.InvalidArgument, "update mask is required")
+	}
+	req.UpdateMask.Normalize()
+
+	before, version, err := c.loadProduct(ctx, req.CategoryId, req.ProductId)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	after := proto.Clone(before).(*pb.Product)
+	after.UpdatedAt = timestamppb.New(now)
+
+	for _, path := range req.UpdateMask.Paths {
+		switch path {
+		case "name":
+			if req.Name == "" {
+				return nil, status.Errorf(codes.InvalidArgument, "name cannot be empty")
+			}
+			after.Name = req.Name
+		case "description":
+			if req.Description == "" {
+				return nil, status.Errorf(codes.InvalidArgument, "description cannot be empty")
+			}
+			after.Description = req.Description
+		case "price":
+			if req.Price <= 0 {
+				return nil, status.Errorf(codes.InvalidArgument, "price must be greater than zero")
+			}
+			after.Price = req.Price
+		case "stock":
+			// a second writer of stock would bypass the reservations checked against the inventory
+			return nil, status.Errorf(codes.InvalidArgument, "stock cannot be updated here, use AdjustStock")
+		default:
+			return nil, status.Errorf(codes.InvalidArgument, "unsupported update mask path %q", path)
+		}
+	}
+
+	updated := &pb.ProductUpdated{
+		Before:        before,
+		After:         after,
+		UpdatedFields: req.UpdateMask.Paths,
+	}
+	row, err := newOutboxRow(ctx, now, events.ProductUpdated, updated)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to marshal product update: %v", err)
+	}
+	if c.eventStore != nil {
+		if err := c.commitProductEvent(ctx, req.ProductId, version, row); err != nil {
+			return nil, err
+		}
+		return &pb.UpdateProductResponse{Product: after}, nil
+	}
+
+	// only the masked columns are written so concurrent updates of other fields are kept
+	columns, values, err := productColumns(after, req.UpdateMask.Paths)
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "%v", err)
+	}
+	columns = append(columns, "pending_events = pending_events + ?")
+	values = append(values, []gocql.UUID{row.ID}, req.CategoryId, req.ProductId)
+
+	pending, err := c.pending.record(ctx, pendingProducts, int64RowKey(req.CategoryId, req.ProductId), row)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to update product: %v", err)
+	}
+
+	// the update is conditional so it cannot bring back a product deleted since it was read
+	applied, err := c.session.Query(
+		`UPDATE products_keyspace_v3.products 
+		SET `+strings.Join(columns, ", ")+` 
+		WHERE category_id = ? AND id = ? 
+		IF EXISTS`,
+		values...,
+	).WithContext(ctx).MapScanCAS(map[string]interface{}{})
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to update product: %v", err)
+	}
+	if !applied {
+		c.pending.discard(ctx, pending)
+		return nil, status.Errorf(codes.NotFound, "product not found")
+	}
+
+	// the product changed and its event is marked on it, the sweep or the next change of the
+	// product writes the event if this fails
+	if err := c.pending.finish(ctx, pending); err != nil {
+		slog.Warn("Product event left pending", "error", err, "productID", req.ProductId)
+	}
+
+	return &pb.UpdateProductResponse{Product: after}, nil
+}
+
+func (c *ProductCommandController) DeleteProduct(ctx context.Context, req *pb.DeleteProductRequest) (*pb.DeleteProductResponse, error) {
+	if req.CategoryId == 0 || req.ProductId == 0 {
+		return nil, status.Errorf(codes.InvalidArgument, "category id and product id are required")
+	}
+
+	now := time.Now()
+	if err := c.deleteProduct(ctx, req.CategoryId, req.ProductId, now); err != nil {
+		return nil, err
+	}
+
+	return &pb.DeleteProductResponse{
+		Id:         req.ProductId,
+		CategoryId: req.CategoryId,
+		DeletedAt:  timestamppb.New(now),
+	}, nil
+}
+
+// SchedulePriceChange records the change as an event delivered at its effective time, the product
+// is only updated when ScheduledPriceChanges receives it.
+func (c *ProductCommandController) SchedulePriceChange(ctx context.Context, req *pb.SchedulePriceChangeRequest) (*pb.SchedulePriceChangeResponse, error) {
+	if req.CategoryId == 0 || req.ProductId == 0 {
+		return nil, status.Errorf(codes.InvalidArgument, "category id and product id are required")
+	}
+	if req.Price <= 0 {
+		return nil, status.Errorf(codes.InvalidArgument, "price must be greater than zero")
+	}
+	if req.EffectiveAt == nil {
+		return nil, status.Errorf(codes.InvalidArgument, "effective at is required")
+	}
+
+	now := time.Now()
+	effectiveAt := req.EffectiveAt.AsTime()
+	if !effectiveAt.After(now) {
+		return nil, status.Errorf(codes.InvalidArgument, "effective at must be in the future")
+	}
+
+	if _, _, err := c.loadProduct(ctx, req.CategoryId, req.ProductId); err != nil {
+		return nil, err
+	}
+
+	scheduled, err := newOutboxRow(ctx, now, events.ProductPriceChangeScheduled, &pb.ProductPriceChangeScheduled{
+		CategoryId:  req.CategoryId,
+		ProductId:   req.ProductId,
+		Price:       req.Price,
+		EffectiveAt: req.EffectiveAt,
+		ScheduledAt: timestamppb.New(now),
+	})
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to marshal price change: %v", err)
+	}
+	scheduled.DeliverAt = effectiveAt
+
+	batch := c.session.NewBatch(gocql.LoggedBatch).WithContext(ctx)
+	scheduled.add(batch)
+
+	if err := c.session.ExecuteBatch(batch); err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to schedule price change: %v", err)
+	}
+	c.outbox.Notify()
+
+	return &pb.SchedulePriceChangeResponse{
+		ScheduleId:  scheduled.ID.String(),
+		EffectiveAt: req.EffectiveAt,
+	}, nil
+}
+
+func (c *ProductCommandController) DeleteCategory(ctx context.Context, req *pb.DeleteCategoryRequest) (*pb.DeleteCategoryResponse, error) {
+	if req.Id == 0 {
+		return nil, status.Errorf(codes.InvalidArgument, "id is required")
+	}
+
+	var categoryId int64
+	err := c.session.Query(
+		`SELECT id FROM products_keyspace_v3.categories WHERE id = ?`,
+		req.Id,
+	).WithContext(ctx).Scan(&categoryId)
+	if err != nil {
+		if err == gocql.ErrNotFound {
+			return nil, status.Errorf(codes.NotFound, "category not found")
+		}
+		return nil, status.Errorf(codes.Internal, "failed to fetch category: %v", err)
+	}
+
+	now := time.Now()
+
+	// products are deleted page by page through their own events ahead of the category, so the event
+	// lists exactly what was removed and a failure part way leaves the category with the products
+	// still to delete for a retry to carry on
+	var productIds []int64
+	var pageState []byte
+	for {
+		iter := c.session.Query(
+			`SELECT id FROM products_keyspace_v3.products WHERE category_id = ?`,
+			req.Id,
+		).WithContext(ctx).PageSize(categoryDeletePageSize).PageState(pageState).Iter()
+
+		var page []int64
+		var productId int64
+		for iter.Scan(&productId) {
+			page = append(page, productId)
+		}
+		pageState = iter.PageState()
+		if err := iter.Close(); err != nil {
+			return nil, status.Errorf(codes.Internal, "failed to list category products: %v", err)
+		}
+
+		if len(page) > 0 && !req.Cascade {
+			return nil, status.Errorf(codes.FailedPrecondition, "category still has products, delete them first or set cascade")
+		}
+		for _, id := range page {
+			err := c.deleteProduct(ctx, req.Id, id, now)
+			if err != nil && status.Code(err) != codes.NotFound {
+				return nil, err
+			}
+		}
+		productIds = append(productIds, page...)
+
+		if len(pageState) == 0 {
+			break
+		}
+	}
 
 	batch := c.session.NewBatch(gocql.LoggedBatch).WithContext(ctx)
 
 	batch.Query(
-		`INSERT INTO products_keyspace_v3.products 
-		(id, name, description, price, stock, category_id, created_at, updated_at) 
-		VALUES (?, ?, ?, ?, ?, ?, ?, ?)`,
-		productId, req.Name, req.Description, req.Price, req.Stock, req.CategoryId, now, now,
+		`DELETE FROM products_keyspace_v3.categories WHERE id = ?`,
+		req.Id,
 	)
-
+	// the partition delete also takes products created in the category while it was being deleted
 	batch.Query(
-		`INSERT INTO products_keyspace_v3.outbox 
-		(id, bucket, payload, event_type) 
-		VALUES (?, ?, ?, ?)`,
-		outboxID, bucket, payload, eventType,
+		`DELETE FROM products_keyspace_v3.products WHERE category_id = ?`,
+		req.Id,
 	)
 
+	err = addOutboxMessage(batch, now, events.CategoryDeleted, &pb.CategoryDeleted{
+		Id:         req.Id,
+		ProductIds: productIds,
+		DeletedAt:  timestamppb.New(now),
+	})
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to marshal category deletion: %v", err)
+	}
+
 	if err := c.session.ExecuteBatch(batch); err != nil {
-		return nil, status.Errorf(codes.Internal, "failed to create product: %v", err)
+		return nil, status.Errorf(codes.Internal, "failed to delete category: %v", err)
 	}
+	c.outbox.Notify()
 
-	return &pb.CreateProductResponse{Product: product}, nil
+	return &pb.DeleteCategoryResponse{
+		Id:                req.Id,
+		DeletedProductIds: productIds,
+		DeletedAt:         timestamppb.New(now),
+	}, nil
+}
+
+// loadProduct returns the current state of a product and its event store version. Products without
+// history, either because event sourcing is off or because they predate it, are read from the products
+// row at version 0 and are adopted by the event store on their next change.
+func (c *ProductCommandController) loadProduct(ctx context.Context, categoryId, productId int64) (*pb.Product, int, error) {
+	// events of earlier changes still pending are finished first so the next change follows them
+	source, key := pendingProducts, int64RowKey(categoryId, productId)
+	if c.eventStore != nil {
+		source, key = pendingProductEvents, productEventsKey(productId)
+	}
+	if err := c.pending.resume(ctx, source, key); err != nil {
+		return nil, 0, status.Errorf(codes.Internal, "failed to finish pending product events: %v", err)
+	}
+
+	if c.eventStore != nil {
+		aggregate, err := eventstore.LoadProduct(ctx, c.eventStore, productId)
+		if err != nil {
+			return nil, 0, status.Errorf(codes.Internal, "failed to load product events: %v", err)
+		}
+		if aggregate.Version > 0 {
+			if aggregate.Deleted || aggregate.Product.GetCategoryId() != categoryId {
+				return nil, 0, status.Errorf(codes.NotFound, "product not found")
+			}
+			return aggregate.Product, aggregate.Version, nil
+		}
+	}
+
+	var product pb.Product
+	var createdAt, updatedAt time.Time
+	err := c.session.Query(
+		`SELECT id, category_id, name, description, price, stock, created_at, updated_at 
+		FROM products_keyspace_v3.products 
+		WHERE category_id = ? AND id = ?`,
+		categoryId, productId,
+	).WithContext(ctx).Scan(
+		&product.Id, &product.CategoryId, &product.Name, &product.Description, &product.Price, &product.Stock, &createdAt, &updatedAt,
+	)
+	if err != nil {
+		if err == gocql.ErrNotFound {
+			return nil, 0, status.Errorf(codes.NotFound, "product not found")
+		}
+		return nil, 0, status.Errorf(codes.Internal, "failed to fetch product: %v", err)
+	}
+	product.CreatedAt = timestamppb.New(createdAt)
+	product.UpdatedAt = timestamppb.New(updatedAt)
+
+	return &product, 0, nil
+}
+
+// commitProductEvent commits a product change written as row after version. With event sourcing the
+// append to the event store is the commit point and marks the event as pending, the products and
+// inventory rows and the outbox are written from the event afterwards. Otherwise they are written in
+// one batch.
+func (c *ProductCommandController) commitProductEvent(ctx context.Context, productId int64, version int, row outboxRow) error {
+	if c.eventStore == nil {
+		batch := c.session.NewBatch(gocql.LoggedBatch).WithContext(ctx)
+		if err := applyProductEvent(batch, row); err != nil {
+			return status.Errorf(codes.Internal, "failed to write %s: %v", row.EventType, err)
+		}
+		row.add(batch)
+		if err := c.session.ExecuteBatch(batch); err != nil {
+			return status.Errorf(codes.Internal, "failed to write %s: %v", row.EventType, err)
+		}
+		c.outbox.Notify()
+		return nil
+	}
+
+	pending, err := c.pending.record(ctx, pendingProductEvents, productEventsKey(productId), row)
+	if err != nil {
+		return status.Errorf(codes.Internal, "failed to write %s: %v", row.EventType, err)
+	}
+
+	err = c.eventStore.Append(ctx, eventstore.Event{
+		AggregateType:  events.ProductAggregate,
+		AggregateID:    productId,
+		Version:        version + 1,
+		EventType:      row.EventType,
+		Payload:        row.Payload,
+		OccurredAt:     row.ID.Time(),
+		PendingEventID: row.ID,
+	})
+	if errors.Is(err, eventstore.ErrVersionConflict) {
+		c.pending.discard(ctx, pending)
+		return status.Errorf(codes.Aborted, "product was modified concurrently, try again")
+	}
+	if err != nil {
+		// the append may have applied all the same, the sweep tells by the mark
+		return status.Errorf(codes.Internal, "failed to append product event: %v", err)
+	}
+
+	// the event is committed and marked on the product, the sweep or the next change of the
+	// product writes the rest if this fails
+	if err := c.pending.finish(ctx, pending); err != nil {
+		slog.Warn("Product event left pending", "error", err, "productID", productId)
+	}
+	return nil
+}
+
+// deleteProduct commits the deletion of a product.
+func (c *ProductCommandController) deleteProduct(ctx context.Context, categoryId, productId int64, now time.Time) error {
+	_, version, err := c.loadProduct(ctx, categoryId, productId)
+	if err != nil {
+		return err
+	}
+
+	row, err := newOutboxRow(ctx, now, events.ProductDeleted, &pb.ProductDeleted{
+		Id:         productId,
+		CategoryId: categoryId,
+		DeletedAt:  timestamppb.New(now),
+	})
+	if err != nil {
+		return status.Errorf(codes.Internal, "failed to marshal product deletion: %v", err)
+	}
+	return c.commitProductEvent(ctx, productId, version, row)
+}
+
+func productEventsKey(productId int64) rowKey {
+	return append(rowKey{events.ProductAggregate}, int64RowKey(productId)...)
+}
+
+// applyProductEvent adds the writes a product event makes to the products and inventory rows. They
+// carry the time of the event, so an event finished late does not undo a later one.
+func applyProductEvent(batch *gocql.Batch, row outboxRow) error {
+	at := row.ID.Time().UnixMicro()
+
+	switch row.EventType {
+	case events.ProductCreated.Name():
+		product, err := events.ProductCreated.Unmarshal(row.Payload)
+		if err != nil {
+			return err
+		}
+		batch.Query(
+			`INSERT INTO products_keyspace_v3.products 
+			(id, name, description, price, stock, category_id, created_at, updated_at) 
+			VALUES (?, ?, ?, ?, ?, ?, ?, ?) 
+			USING TIMESTAMP ?`,
+			product.Id, product.Name, product.Description, product.Price, product.Stock, product.CategoryId,
+			product.CreatedAt.AsTime(), product.UpdatedAt.AsTime(), at,
+		)
+		batch.Query(
+			`INSERT INTO products_keyspace_v3.inventory 
+			(product_id, category_id, stock_count, reserved_count, created_at, last_updated_at) 
+			VALUES (?, ?, ?, ?, ?, ?) 
+			USING TIMESTAMP ?`,
+			product.Id, product.CategoryId, product.Stock, 0, product.CreatedAt.AsTime(), product.CreatedAt.AsTime(), at,
+		)
+	case events.ProductUpdated.Name():
+		updated, err := events.ProductUpdated.Unmarshal(row.Payload)
+		if err != nil {
+			return err
+		}
+		columns, values, err := productColumns(updated.After, updated.UpdatedFields)
+		if err != nil {
+			return err
+		}
+		values = append([]interface{}{at}, values...)
+		batch.Query(
+			`UPDATE products_keyspace_v3.products USING TIMESTAMP ? 
+			SET `+strings.Join(columns, ", ")+` 
+			WHERE category_id = ? AND id = ?`,
+			append(values, updated.After.CategoryId, updated.After.Id)...,
+		)
+	case events.ProductDeleted.Name():
+		deleted, err := events.ProductDeleted.Unmarshal(row.Payload)
+		if err != nil {
+			return err
+		}
+		batch.Query(
+			`DELETE FROM products_keyspace_v3.products USING TIMESTAMP ? WHERE category_id = ? AND id = ?`,
+			at, deleted.CategoryId, deleted.Id,
+		)
+		batch.Query(
+			`DELETE FROM products_keyspace_v3.inventory USING TIMESTAMP ? WHERE product_id = ? AND category_id = ?`,
+			at, deleted.Id, deleted.CategoryId,
+		)
+	default:
+		return fmt.Errorf("unknown product event type %q", row.EventType)
+	}
+	return nil
+}
+
+// productColumns returns the assignments writing the fields in paths of product, along with its
+// updated_at.
+func productColumns(product *pb.Product, paths []string) ([]string, []interface{}, error) {
+	var columns []string
+	var values []interface{}
+	for _, path := range paths {
+		switch path {
+		case "name":
+			values = append(values, product.Name)
+		case "description":
+			values = append(values, product.Description)
+		case "price":
+			values = append(values, product.Price)
+		default:
+			return nil, nil, fmt.Errorf("unsupported update mask path %q", path)
+		}
+		columns = append(columns, path+" = ?")
+	}
+	columns = append(columns, "updated_at = ?")
+	values = append(values, product.UpdatedAt.AsTime())
+	return columns, values, nil
 }
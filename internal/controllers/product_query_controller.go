@@ -6,6 +6,7 @@ import (
 
 	"github.com/gocql/gocql"
 	"github.com/yaninyzwitty/cqrs-eccomerce-service/internal/database"
+	"github.com/yaninyzwitty/cqrs-eccomerce-service/internal/projection"
 	"github.com/yaninyzwitty/cqrs-eccomerce-service/pb"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
@@ -27,7 +28,7 @@ func (c *ProductQueryController) GetCategory(ctx context.Context, req *pb.GetCat
 		return nil, status.Errorf(codes.InvalidArgument, "id is required")
 	}
 
-	getCategoryQuery := `SELECT id, name, description, created_at FROM products_keyspace_v3.categories WHERE id = ?`
+	getCategoryQuery := `SELECT id, name, description, created_at FROM products_keyspace_v3.categories_view WHERE id = ?`
 	var categoryId int64
 	var name, description string
 	var createdAt time.Time
@@ -47,6 +48,50 @@ func (c *ProductQueryController) GetCategory(ctx context.Context, req *pb.GetCat
 	}, nil
 }
 
+func (c *ProductQueryController) ListCategories(ctx context.Context, req *pb.ListCategoriesRequest) (*pb.ListCategoriesResponse, error) {
+	if req.PageSize <= 0 {
+		return nil, status.Errorf(codes.InvalidArgument, "page size is required")
+	}
+
+	query := c.session.Query(`
+		SELECT id, name, description, created_at 
+		FROM products_keyspace_v3.category_listing_view 
+		WHERE listing = ?`,
+		projection.CategoryListing,
+	).WithContext(ctx).PageSize(int(req.PageSize)).PageState(req.PagingState)
+
+	iter := query.Iter()
+	defer iter.Close()
+
+	var categories []*pb.Category
+	var (
+		id          int64
+		name        string
+		description string
+		createdAt   time.Time
+	)
+
+	for iter.Scan(&id, &name, &description, &createdAt) {
+		categories = append(categories, &pb.Category{
+			Id:          id,
+			Name:        name,
+			Description: description,
+			CreatedAt:   timestamppb.New(createdAt),
+		})
+	}
+
+	nextPageState := iter.PageState()
+
+	if err := iter.Close(); err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to list categories: %v", err)
+	}
+
+	return &pb.ListCategoriesResponse{
+		Categories:  categories,
+		PagingState: nextPageState,
+	}, nil
+}
+
 func (c *ProductQueryController) GetProduct(ctx context.Context, req *pb.GetProductRequest) (*pb.GetProductResponse, error) {
 	if req.CategoryId == 0 || req.ProductId == 0 {
 		return nil, status.Errorf(codes.InvalidArgument, "category id and product id are required")
@@ -55,7 +100,7 @@ func (c *ProductQueryController) GetProduct(ctx context.Context, req *pb.GetProd
 	var product pb.Product
 	var createdAt, updatedAt time.Time
 
-	getProductQuery := `SELECT id, name, description, price, stock, category_id, created_at, updated_at FROM products_keyspace_v3.products WHERE category_id = ? AND id = ?`
+	getProductQuery := `SELECT id, name, description, price, stock, category_id, created_at, updated_at FROM products_keyspace_v3.products_view WHERE category_id = ? AND id = ?`
 	err := c.session.Query(getProductQuery, req.CategoryId, req.ProductId).WithContext(ctx).Scan(
 		&product.Id, &product.Name, &product.Description, &product.Price, &product.Stock, &product.CategoryId, &createdAt, &updatedAt,
 	)
@@ -79,7 +124,7 @@ func (c *ProductQueryController) ListProducts(ctx context.Context, req *pb.ListP
 
 	query := c.session.Query(`
 		SELECT id, name, description, price, stock, created_at, updated_at 
-		FROM products_keyspace_v3.products 
+		FROM products_keyspace_v3.products_view 
 		WHERE category_id = ?`,
 		req.CategoryId,
 	).WithContext(ctx).PageSize(int(req.PageSize)).PageState(req.PagingState)
@@ -0,0 +1,58 @@
+package controllers
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/yaninyzwitty/cqrs-eccomerce-service/internal/events"
+	"github.com/yaninyzwitty/cqrs-eccomerce-service/pb"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/fieldmaskpb"
+)
+
+// ScheduledPriceChanges applies scheduled price changes when their events arrive. The change goes
+// through UpdateProduct, so it is versioned and published like one made by hand.
+type ScheduledPriceChanges struct {
+	products *ProductCommandController
+}
+
+func NewScheduledPriceChanges(products *ProductCommandController) *ScheduledPriceChanges {
+	return &ScheduledPriceChanges{products: products}
+}
+
+func (s *ScheduledPriceChanges) Project(ctx context.Context, eventType string, payload []byte) error {
+	if eventType != events.ProductPriceChangeScheduled.Name() {
+		return nil
+	}
+
+	change, err := events.ProductPriceChangeScheduled.Unmarshal(string(payload))
+	if err != nil {
+		return fmt.Errorf("error unmarshalling price change: %w", err)
+	}
+
+	// a subscription that does not support delayed delivery receives the event early, failing
+	// it has the broker deliver it again later
+	if wait := time.Until(change.EffectiveAt.AsTime()); wait > 0 {
+		return fmt.Errorf("price change of product %d is not due for %v", change.ProductId, wait.Round(time.Second))
+	}
+
+	_, err = s.products.UpdateProduct(ctx, &pb.UpdateProductRequest{
+		CategoryId: change.CategoryId,
+		ProductId:  change.ProductId,
+		Price:      change.Price,
+		UpdateMask: &fieldmaskpb.FieldMask{Paths: []string{"price"}},
+	})
+	if status.Code(err) == codes.NotFound {
+		slog.Warn("Product deleted before its scheduled price change", "productID", change.ProductId)
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	slog.Info("Scheduled price change applied", "productID", change.ProductId, "price", change.Price)
+	return nil
+}
@@ -0,0 +1,40 @@
+package controllers
+
+import (
+	"context"
+
+	"github.com/gocql/gocql"
+	"github.com/yaninyzwitty/cqrs-eccomerce-service/internal/events"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+)
+
+// metadata keys a caller sets to tie the events of a command to its own request
+const (
+	correlationIDHeader = "x-correlation-id"
+	requestIDHeader     = "x-request-id"
+)
+
+// TraceInterceptor puts the trace of a command on its context, the request id becomes the
+// causation id of the events it writes. A command without a correlation id starts a new chain.
+func TraceInterceptor(ctx context.Context, req any, _ *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+	md, _ := metadata.FromIncomingContext(ctx)
+	trace := events.Trace{
+		CorrelationID: firstValue(md, correlationIDHeader),
+		CausationID:   firstValue(md, requestIDHeader),
+	}
+	if trace.CausationID == "" {
+		trace.CausationID = gocql.TimeUUID().String()
+	}
+	if trace.CorrelationID == "" {
+		trace.CorrelationID = trace.CausationID
+	}
+	return handler(events.WithTrace(ctx, trace), req)
+}
+
+func firstValue(md metadata.MD, key string) string {
+	if values := md.Get(key); len(values) > 0 {
+		return values[0]
+	}
+	return ""
+}
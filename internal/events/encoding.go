@@ -0,0 +1,71 @@
+package events
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/yaninyzwitty/cqrs-eccomerce-service/pb"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+// Encoding is the wire format of published event payloads.
+type Encoding string
+
+const (
+	EncodingJSON Encoding = "json"
+	// EncodingProtobuf publishes every event as a binary pb.DomainEvent.
+	EncodingProtobuf Encoding = "protobuf"
+)
+
+// content types published in the envelope
+const (
+	ContentTypeJSON     = "application/json"
+	ContentTypeProtobuf = "application/x-protobuf"
+)
+
+// ParseEncoding reads the encoding from the config, JSON stays the default.
+func ParseEncoding(encoding string) (Encoding, error) {
+	switch Encoding(encoding) {
+	case "", EncodingJSON:
+		return EncodingJSON, nil
+	case EncodingProtobuf:
+		return EncodingProtobuf, nil
+	default:
+		return "", fmt.Errorf("unknown event encoding %q", encoding)
+	}
+}
+
+func (e Encoding) ContentType() string {
+	if e == EncodingProtobuf {
+		return ContentTypeProtobuf
+	}
+	return ContentTypeJSON
+}
+
+// domainEventField returns the pb.DomainEvent field carrying the payload of eventType.
+func domainEventField(eventType string) protoreflect.FieldDescriptor {
+	name := protoreflect.Name(strings.ReplaceAll(eventType, ".", "_"))
+	return (&pb.DomainEvent{}).ProtoReflect().Descriptor().Fields().ByName(name)
+}
+
+func marshalDomainEvent(eventType string, payload proto.Message) ([]byte, error) {
+	event := &pb.DomainEvent{}
+	event.ProtoReflect().Set(domainEventField(eventType), protoreflect.ValueOfMessage(payload.ProtoReflect()))
+	return proto.Marshal(event)
+}
+
+// DecodeDomainEvent returns the payload of a protobuf encoded event.
+func DecodeDomainEvent(data []byte) (proto.Message, error) {
+	var event pb.DomainEvent
+	if err := proto.Unmarshal(data, &event); err != nil {
+		return nil, fmt.Errorf("error unmarshalling domain event: %w", err)
+	}
+
+	reflected := event.ProtoReflect()
+	field := reflected.WhichOneof(reflected.Descriptor().Oneofs().ByName("payload"))
+	if field == nil {
+		return nil, fmt.Errorf("domain event has no payload")
+	}
+	return reflected.Get(field).Message().Interface(), nil
+}
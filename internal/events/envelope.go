@@ -0,0 +1,124 @@
+package events
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"time"
+)
+
+// message properties carrying the envelope
+const (
+	PropertyEventID       = "event_id"
+	PropertyEventType     = "event_type"
+	PropertySchemaVersion = "schema_version"
+	PropertyAggregateType = "aggregate_type"
+	PropertyAggregateID   = "aggregate_id"
+	PropertyOccurredAt    = "occurred_at"
+	PropertyProducer      = "producer"
+	PropertyCorrelationID = "correlation_id"
+	PropertyCausationID   = "causation_id"
+	PropertyContentType   = "content_type"
+	PropertyDeliverAt     = "deliver_at"
+)
+
+// Envelope is the metadata published with every event, so consumers can route and deduplicate
+// without parsing the payload.
+type Envelope struct {
+	// EventID is the id of the outbox row, it stays the same when the row is published again.
+	EventID       string
+	EventType     string
+	SchemaVersion int
+	AggregateType string
+	AggregateID   string
+	OccurredAt    time.Time
+	// Producer names the service that wrote the event.
+	Producer string
+	// CorrelationID is shared by every event caused by the same request, CausationID is the id
+	// of the request or event that directly caused this one.
+	CorrelationID string
+	CausationID   string
+	// ContentType tells JSON payloads from protobuf encoded ones.
+	ContentType string
+	// DeliverAt is set on scheduled events, which are not delivered before.
+	DeliverAt time.Time
+}
+
+func (e Envelope) Properties() map[string]string {
+	properties := map[string]string{
+		PropertyEventID:       e.EventID,
+		PropertyEventType:     e.EventType,
+		PropertySchemaVersion: strconv.Itoa(e.SchemaVersion),
+		PropertyAggregateType: e.AggregateType,
+		PropertyAggregateID:   e.AggregateID,
+		PropertyOccurredAt:    e.OccurredAt.UTC().Format(time.RFC3339Nano),
+		PropertyProducer:      e.Producer,
+		PropertyContentType:   e.ContentType,
+	}
+	if e.CorrelationID != "" {
+		properties[PropertyCorrelationID] = e.CorrelationID
+	}
+	if e.CausationID != "" {
+		properties[PropertyCausationID] = e.CausationID
+	}
+	if !e.DeliverAt.IsZero() {
+		properties[PropertyDeliverAt] = e.DeliverAt.UTC().Format(time.RFC3339Nano)
+	}
+	return properties
+}
+
+// EnvelopeFromProperties reads the envelope of a received message. It fails for messages
+// published without one.
+func EnvelopeFromProperties(properties map[string]string) (Envelope, error) {
+	if properties[PropertyEventID] == "" || properties[PropertyEventType] == "" {
+		return Envelope{}, fmt.Errorf("message has no event envelope")
+	}
+
+	schemaVersion, err := strconv.Atoi(properties[PropertySchemaVersion])
+	if err != nil {
+		return Envelope{}, fmt.Errorf("invalid schema version %q: %w", properties[PropertySchemaVersion], err)
+	}
+	occurredAt, err := time.Parse(time.RFC3339Nano, properties[PropertyOccurredAt])
+	if err != nil {
+		return Envelope{}, fmt.Errorf("invalid occurred at %q: %w", properties[PropertyOccurredAt], err)
+	}
+
+	var deliverAt time.Time
+	if value := properties[PropertyDeliverAt]; value != "" {
+		deliverAt, err = time.Parse(time.RFC3339Nano, value)
+		if err != nil {
+			return Envelope{}, fmt.Errorf("invalid deliver at %q: %w", value, err)
+		}
+	}
+
+	return Envelope{
+		EventID:       properties[PropertyEventID],
+		EventType:     properties[PropertyEventType],
+		SchemaVersion: schemaVersion,
+		AggregateType: properties[PropertyAggregateType],
+		AggregateID:   properties[PropertyAggregateID],
+		OccurredAt:    occurredAt,
+		Producer:      properties[PropertyProducer],
+		CorrelationID: properties[PropertyCorrelationID],
+		CausationID:   properties[PropertyCausationID],
+		ContentType:   properties[PropertyContentType],
+		DeliverAt:     deliverAt,
+	}, nil
+}
+
+type traceKey struct{}
+
+// Trace links an event to the request that caused it.
+type Trace struct {
+	CorrelationID string
+	CausationID   string
+}
+
+func WithTrace(ctx context.Context, trace Trace) context.Context {
+	return context.WithValue(ctx, traceKey{}, trace)
+}
+
+func TraceFromContext(ctx context.Context) Trace {
+	trace, _ := ctx.Value(traceKey{}).(Trace)
+	return trace
+}
@@ -0,0 +1,91 @@
+package events
+
+import (
+	"slices"
+	"strconv"
+
+	"github.com/yaninyzwitty/cqrs-eccomerce-service/pb"
+	"google.golang.org/protobuf/proto"
+)
+
+// aggregate types carried in the event envelope
+const (
+	CategoryAggregate  = "category"
+	ProductAggregate   = "product"
+	InventoryAggregate = "inventory"
+	OrderAggregate     = "order"
+	CartAggregate      = "cart"
+)
+
+var (
+	CategoryCreated = define("category.created", 1, CategoryAggregate,
+		func() *pb.Category { return &pb.Category{} },
+		func(c *pb.Category) string { return id(c.Id) })
+	CategoryDeleted = define("category.deleted", 1, CategoryAggregate,
+		func() *pb.CategoryDeleted { return &pb.CategoryDeleted{} },
+		func(c *pb.CategoryDeleted) string { return id(c.Id) })
+
+	ProductCreated = define("product.created", 1, ProductAggregate,
+		func() *pb.Product { return &pb.Product{} },
+		func(p *pb.Product) string { return id(p.Id) })
+	ProductUpdated = define("product.updated", 1, ProductAggregate,
+		func() *pb.ProductUpdated { return &pb.ProductUpdated{} },
+		func(p *pb.ProductUpdated) string { return id(p.After.GetId()) })
+	ProductDeleted = define("product.deleted", 1, ProductAggregate,
+		func() *pb.ProductDeleted { return &pb.ProductDeleted{} },
+		func(p *pb.ProductDeleted) string { return id(p.Id) })
+	ProductPriceChangeScheduled = define("product.price_change_scheduled", 1, ProductAggregate,
+		func() *pb.ProductPriceChangeScheduled { return &pb.ProductPriceChangeScheduled{} },
+		func(p *pb.ProductPriceChangeScheduled) string { return id(p.ProductId) })
+
+	InventoryReserved = define("inventory.reserved", 1, InventoryAggregate, newStockChanged, stockProductID)
+	InventoryReleased = define("inventory.released", 1, InventoryAggregate, newStockChanged, stockProductID)
+	InventoryAdjusted = define("inventory.adjusted", 1, InventoryAggregate, newStockChanged, stockProductID)
+
+	OrderCreated = define("order.created", 1, OrderAggregate,
+		func() *pb.Order { return &pb.Order{} },
+		func(o *pb.Order) string { return id(o.Id) })
+	OrderStatusChanged = define("order.status_changed", 1, OrderAggregate,
+		func() *pb.OrderStatusChanged { return &pb.OrderStatusChanged{} },
+		func(o *pb.OrderStatusChanged) string { return id(o.OrderId) })
+
+	CartItemAdded   = define("cart.item_added", 1, CartAggregate, newCartItemChanged, cartID)
+	CartItemRemoved = define("cart.item_removed", 1, CartAggregate, newCartItemChanged, cartID)
+)
+
+// catalog collects the events declared above in declaration order.
+var catalog []Definition
+
+// define declares an event the services publish and adds it to the catalog.
+func define[T proto.Message](name string, schemaVersion int, aggregateType string, newPayload func() T, aggregateID func(T) string) *Event[T] {
+	event := NewEvent(name, schemaVersion, aggregateType, newPayload, aggregateID)
+	catalog = append(catalog, event)
+	return event
+}
+
+// Catalog lists every event the services publish, declaring an event above is all it takes to
+// have the relay accept it.
+func Catalog() []Definition {
+	return slices.Clone(catalog)
+}
+
+func id(id int64) string {
+	return strconv.FormatInt(id, 10)
+}
+
+func newStockChanged() *pb.StockChanged {
+	return &pb.StockChanged{}
+}
+
+// stock is kept per product, so the product id identifies the inventory aggregate
+func stockProductID(changed *pb.StockChanged) string {
+	return id(changed.Stock.GetProductId())
+}
+
+func newCartItemChanged() *pb.CartItemChanged {
+	return &pb.CartItemChanged{}
+}
+
+func cartID(changed *pb.CartItemChanged) string {
+	return changed.CartId
+}
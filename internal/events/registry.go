@@ -0,0 +1,195 @@
+package events
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+// eventTypeField is the payload field the relay stamps with the event name before publishing.
+const eventTypeField = "event_type"
+
+// Definition describes an event type carried by the outbox, independent of its payload type.
+type Definition interface {
+	Name() string
+	SchemaVersion() int
+	// AggregateType names the kind of entity the event belongs to.
+	AggregateType() string
+	// PayloadType is the Go type stored in the outbox and published to the queue.
+	PayloadType() reflect.Type
+	// Transform turns an outbox payload into the message published to the queue.
+	Transform(payload string, encoding Encoding) (Transformed, error)
+	validate() error
+}
+
+// Transformed is an outbox payload ready to publish along with the aggregate it belongs to.
+type Transformed struct {
+	Payload     []byte
+	AggregateID string
+}
+
+// Event is the typed definition of one event type, controllers encode outbox payloads through it
+// so a payload can only be written with the Go type its event declares.
+type Event[T proto.Message] struct {
+	name          string
+	schemaVersion int
+	aggregateType string
+	newPayload    func() T
+	aggregateID   func(T) string
+}
+
+func NewEvent[T proto.Message](name string, schemaVersion int, aggregateType string, newPayload func() T, aggregateID func(T) string) *Event[T] {
+	return &Event[T]{
+		name:          name,
+		schemaVersion: schemaVersion,
+		aggregateType: aggregateType,
+		newPayload:    newPayload,
+		aggregateID:   aggregateID,
+	}
+}
+
+func (e *Event[T]) Name() string {
+	return e.name
+}
+
+func (e *Event[T]) SchemaVersion() int {
+	return e.schemaVersion
+}
+
+func (e *Event[T]) AggregateType() string {
+	return e.aggregateType
+}
+
+func (e *Event[T]) PayloadType() reflect.Type {
+	return reflect.TypeOf(e.newPayload())
+}
+
+// Marshal encodes the payload of an outbox row.
+func (e *Event[T]) Marshal(payload T) ([]byte, error) {
+	return json.Marshal(payload)
+}
+
+// Unmarshal decodes the payload of an outbox row.
+func (e *Event[T]) Unmarshal(payload string) (T, error) {
+	message := e.newPayload()
+	if err := json.Unmarshal([]byte(payload), message); err != nil {
+		return message, fmt.Errorf("error unmarshalling %s payload: %w", e.name, err)
+	}
+	return message, nil
+}
+
+// Transform stamps the event name on the payload so consumers can tell events apart and encodes
+// it for publishing.
+func (e *Event[T]) Transform(payload string, encoding Encoding) (Transformed, error) {
+	message, err := e.Unmarshal(payload)
+	if err != nil {
+		return Transformed{}, err
+	}
+
+	reflected := message.ProtoReflect()
+	reflected.Set(reflected.Descriptor().Fields().ByName(eventTypeField), protoreflect.ValueOfString(e.name))
+
+	var published []byte
+	if encoding == EncodingProtobuf {
+		published, err = marshalDomainEvent(e.name, message)
+	} else {
+		published, err = json.Marshal(message)
+	}
+	if err != nil {
+		return Transformed{}, fmt.Errorf("error marshalling %s payload: %w", e.name, err)
+	}
+	return Transformed{Payload: published, AggregateID: e.aggregateID(message)}, nil
+}
+
+func (e *Event[T]) validate() error {
+	if e.name == "" {
+		return fmt.Errorf("event with payload %v has no name", e.PayloadType())
+	}
+	if e.aggregateType == "" || e.aggregateID == nil {
+		return fmt.Errorf("event %s does not declare its aggregate", e.name)
+	}
+	if e.schemaVersion < 1 {
+		return fmt.Errorf("event %s has schema version %d, versions start at 1", e.name, e.schemaVersion)
+	}
+
+	descriptor := e.newPayload().ProtoReflect().Descriptor()
+	field := descriptor.Fields().ByName(eventTypeField)
+	if field == nil || field.Kind() != protoreflect.StringKind {
+		return fmt.Errorf("event %s payload %v has no string %s field", e.name, e.PayloadType(), eventTypeField)
+	}
+
+	wrapper := domainEventField(e.name)
+	if wrapper == nil || wrapper.Message() == nil || wrapper.Message().FullName() != descriptor.FullName() {
+		return fmt.Errorf("event %s has no %s field in DomainEvent", e.name, descriptor.FullName())
+	}
+	return nil
+}
+
+// Registry resolves the definition of an outbox row by its event type.
+type Registry struct {
+	definitions map[string]Definition
+}
+
+// NewRegistry fails when a definition is invalid or two definitions share a name.
+func NewRegistry(definitions ...Definition) (*Registry, error) {
+	r := &Registry{definitions: make(map[string]Definition, len(definitions))}
+	for _, definition := range definitions {
+		if err := definition.validate(); err != nil {
+			return nil, err
+		}
+		if _, ok := r.definitions[definition.Name()]; ok {
+			return nil, fmt.Errorf("event %s is registered twice", definition.Name())
+		}
+		r.definitions[definition.Name()] = definition
+	}
+	return r, nil
+}
+
+func (r *Registry) Lookup(name string) (Definition, bool) {
+	definition, ok := r.definitions[name]
+	return definition, ok
+}
+
+// Definitions lists the registered events by name.
+func (r *Registry) Definitions() []Definition {
+	definitions := make([]Definition, 0, len(r.definitions))
+	for _, definition := range r.definitions {
+		definitions = append(definitions, definition)
+	}
+	sort.Slice(definitions, func(i, j int) bool { return definitions[i].Name() < definitions[j].Name() })
+	return definitions
+}
+
+// AggregateTypes lists the aggregate types of the registered events.
+func (r *Registry) AggregateTypes() []string {
+	seen := make(map[string]bool)
+	var types []string
+	for _, definition := range r.definitions {
+		if !seen[definition.AggregateType()] {
+			seen[definition.AggregateType()] = true
+			types = append(types, definition.AggregateType())
+		}
+	}
+	sort.Strings(types)
+	return types
+}
+
+// Require fails when any of the event types has no definition.
+func (r *Registry) Require(names ...string) error {
+	var missing []string
+	for _, name := range names {
+		if _, ok := r.definitions[name]; !ok {
+			missing = append(missing, name)
+		}
+	}
+	if len(missing) > 0 {
+		sort.Strings(missing)
+		return fmt.Errorf("events not registered: %s", strings.Join(missing, ", "))
+	}
+	return nil
+}
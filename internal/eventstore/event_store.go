@@ -0,0 +1,98 @@
+package eventstore
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/gocql/gocql"
+)
+
+// ErrVersionConflict is returned when another writer already appended the version being written.
+var ErrVersionConflict = errors.New("aggregate was modified concurrently")
+
+// Event is a single entry in the history of an aggregate.
+type Event struct {
+	AggregateType string
+	AggregateID   int64
+	Version       int
+	EventType     string
+	Payload       string
+	OccurredAt    time.Time
+	// PendingEventID is added to the pending_events of the aggregate by the append when set, it
+	// marks the writes derived from the event as owed until they are made.
+	PendingEventID gocql.UUID
+}
+
+// EventStore defines the methods for reading and appending aggregate histories.
+type EventStore interface {
+	Load(ctx context.Context, aggregateType string, aggregateID int64) ([]Event, error)
+	Append(ctx context.Context, event Event) error
+}
+
+type CassandraEventStore struct {
+	session *gocql.Session
+}
+
+func NewCassandraEventStore(session *gocql.Session) *CassandraEventStore {
+	return &CassandraEventStore{session: session}
+}
+
+// Load returns the events of an aggregate in version order.
+func (s *CassandraEventStore) Load(ctx context.Context, aggregateType string, aggregateID int64) ([]Event, error) {
+	iter := s.session.Query(
+		`SELECT version, event_type, payload, occurred_at 
+		FROM products_keyspace_v3.event_store 
+		WHERE aggregate_type = ? AND aggregate_id = ?`,
+		aggregateType, aggregateID,
+	).WithContext(ctx).Iter()
+
+	var events []Event
+	for {
+		event := Event{AggregateType: aggregateType, AggregateID: aggregateID}
+		if !iter.Scan(&event.Version, &event.EventType, &event.Payload, &event.OccurredAt) {
+			break
+		}
+		events = append(events, event)
+	}
+
+	if err := iter.Close(); err != nil {
+		return nil, fmt.Errorf("failed to load events: %w", err)
+	}
+	return events, nil
+}
+
+// Append writes event at event.Version, which must be the version the caller loaded plus one.
+// The insert is a lightweight transaction so only one of two racing writers can claim a version,
+// the mark of the pending event shares its partition and is only written along with it.
+func (s *CassandraEventStore) Append(ctx context.Context, event Event) error {
+	batch := s.session.NewBatch(gocql.UnloggedBatch).WithContext(ctx)
+	batch.Query(
+		`INSERT INTO products_keyspace_v3.event_store 
+		(aggregate_type, aggregate_id, version, event_type, payload, occurred_at) 
+		VALUES (?, ?, ?, ?, ?, ?) 
+		IF NOT EXISTS`,
+		event.AggregateType, event.AggregateID, event.Version, event.EventType, event.Payload, event.OccurredAt,
+	)
+	if event.PendingEventID != (gocql.UUID{}) {
+		batch.Query(
+			`UPDATE products_keyspace_v3.event_store 
+			SET pending_events = pending_events + ? 
+			WHERE aggregate_type = ? AND aggregate_id = ?`,
+			[]gocql.UUID{event.PendingEventID}, event.AggregateType, event.AggregateID,
+		)
+	}
+
+	applied, iter, err := s.session.MapExecuteBatchCAS(batch, map[string]interface{}{})
+	if iter != nil {
+		iter.Close()
+	}
+	if err != nil {
+		return fmt.Errorf("failed to append event: %w", err)
+	}
+	if !applied {
+		return ErrVersionConflict
+	}
+	return nil
+}
@@ -0,0 +1,60 @@
+package eventstore
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/yaninyzwitty/cqrs-eccomerce-service/internal/events"
+	"github.com/yaninyzwitty/cqrs-eccomerce-service/pb"
+)
+
+// ProductAggregate is a product rebuilt from its events, Version 0 means it has no history.
+type ProductAggregate struct {
+	Product *pb.Product
+	Version int
+	Deleted bool
+}
+
+// LoadProduct rehydrates a product by replaying its events in order.
+func LoadProduct(ctx context.Context, store EventStore, productID int64) (*ProductAggregate, error) {
+	history, err := store.Load(ctx, events.ProductAggregate, productID)
+	if err != nil {
+		return nil, err
+	}
+
+	aggregate := &ProductAggregate{}
+	for _, event := range history {
+		if err := aggregate.Apply(event); err != nil {
+			return nil, err
+		}
+	}
+	return aggregate, nil
+}
+
+// Apply folds a single event into the aggregate state.
+func (a *ProductAggregate) Apply(event Event) error {
+	switch event.EventType {
+	case events.ProductCreated.Name():
+		var product pb.Product
+		if err := json.Unmarshal([]byte(event.Payload), &product); err != nil {
+			return fmt.Errorf("error unmarshalling product: %w", err)
+		}
+		a.Product = &product
+		a.Deleted = false
+	case events.ProductUpdated.Name():
+		// updates carry the full new state, so products without a created event still rehydrate
+		var update pb.ProductUpdated
+		if err := json.Unmarshal([]byte(event.Payload), &update); err != nil {
+			return fmt.Errorf("error unmarshalling product update: %w", err)
+		}
+		a.Product = update.After
+	case events.ProductDeleted.Name():
+		a.Deleted = true
+	default:
+		return fmt.Errorf("unknown product event type %q at version %d", event.EventType, event.Version)
+	}
+
+	a.Version = event.Version
+	return nil
+}
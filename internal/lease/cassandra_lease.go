@@ -0,0 +1,116 @@
+package lease
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"sync"
+	"time"
+
+	"github.com/gocql/gocql"
+)
+
+// DefaultTTL applies when the config does not set a lease ttl.
+const DefaultTTL = 15 * time.Second
+
+// Lease is a named lock owned by at most one process at a time. Ownership lapses when the
+// holder stops renewing it, so another process can take over after a crash.
+type Lease interface {
+	// Acquire takes or renews the lease and reports whether the caller holds it.
+	Acquire(ctx context.Context) (bool, error)
+	// Expiry is when the lease lapses unless it is renewed.
+	Expiry() time.Time
+	Release(ctx context.Context) error
+}
+
+// CassandraLease stores the owner in a row that expires with the lease TTL; every write is an
+// LWT so two processes never both believe they hold it.
+type CassandraLease struct {
+	session *gocql.Session
+	name    string
+	owner   string
+	ttl     time.Duration
+
+	mu        sync.Mutex
+	expiresAt time.Time
+}
+
+func NewCassandraLease(session *gocql.Session, name, owner string, ttl time.Duration) *CassandraLease {
+	if ttl < time.Second {
+		ttl = DefaultTTL
+	}
+	return &CassandraLease{session: session, name: name, owner: owner, ttl: ttl}
+}
+
+func (l *CassandraLease) Acquire(ctx context.Context) (bool, error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	// the TTL starts when Cassandra applies the write, so timing from before the request
+	// never overestimates how long the lease is held
+	start := time.Now()
+	ttlSeconds := int(l.ttl / time.Second)
+
+	if !l.expiresAt.IsZero() {
+		applied, err := l.session.Query(
+			`UPDATE products_keyspace_v3.leases USING TTL ?
+			SET owner = ? WHERE name = ? IF owner = ?`,
+			ttlSeconds, l.owner, l.name, l.owner,
+		).WithContext(ctx).MapScanCAS(map[string]interface{}{})
+		if err != nil {
+			l.expiresAt = time.Time{}
+			return false, fmt.Errorf("failed to renew lease %s: %w", l.name, err)
+		}
+		if applied {
+			l.expiresAt = start.Add(l.ttl)
+			return true, nil
+		}
+		slog.Warn("Lost lease", "lease", l.name, "owner", l.owner)
+		l.expiresAt = time.Time{}
+	}
+
+	existing := map[string]interface{}{}
+	applied, err := l.session.Query(
+		`INSERT INTO products_keyspace_v3.leases (name, owner)
+		VALUES (?, ?)
+		IF NOT EXISTS
+		USING TTL ?`,
+		l.name, l.owner, ttlSeconds,
+	).WithContext(ctx).MapScanCAS(existing)
+	if err != nil {
+		return false, fmt.Errorf("failed to acquire lease %s: %w", l.name, err)
+	}
+	if !applied {
+		return false, nil
+	}
+
+	l.expiresAt = start.Add(l.ttl)
+	slog.Info("Acquired lease", "lease", l.name, "owner", l.owner, "ttl", l.ttl)
+	return true, nil
+}
+
+func (l *CassandraLease) Expiry() time.Time {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return l.expiresAt
+}
+
+// Release gives the lease up early so another process does not have to wait for it to lapse.
+func (l *CassandraLease) Release(ctx context.Context) error {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if l.expiresAt.IsZero() {
+		return nil
+	}
+	l.expiresAt = time.Time{}
+
+	_, err := l.session.Query(
+		`DELETE FROM products_keyspace_v3.leases WHERE name = ? IF owner = ?`,
+		l.name, l.owner,
+	).WithContext(ctx).MapScanCAS(map[string]interface{}{})
+	if err != nil {
+		return fmt.Errorf("failed to release lease %s: %w", l.name, err)
+	}
+	return nil
+}
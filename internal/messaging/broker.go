@@ -0,0 +1,78 @@
+package messaging
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/yaninyzwitty/cqrs-eccomerce-service/internal/queue"
+)
+
+// brokers selectable with queue.broker
+const (
+	BrokerPulsar = "pulsar"
+	BrokerKafka  = "kafka"
+	BrokerMemory = "memory"
+)
+
+// Message is an event received from a broker.
+type Message interface {
+	// ID identifies the message within its broker, for logging.
+	ID() string
+	Topic() string
+	Key() string
+	Payload() []byte
+	Properties() map[string]string
+	PublishTime() time.Time
+}
+
+// Consumer receives messages of a subscription. Every received message must be acked once it
+// was handled or nacked so the broker delivers it again.
+type Consumer interface {
+	Receive(ctx context.Context) (Message, error)
+	Ack(msg Message) error
+	Nack(msg Message)
+	Close()
+}
+
+// Broker creates producers and consumers of a topic.
+type Broker interface {
+	NewProducer(ctx context.Context, topic string) (MessageProducer, error)
+	NewConsumer(ctx context.Context, topic string) (Consumer, error)
+	// DelayedDelivery reports whether the broker holds messages until their envelope's
+	// DeliverAt, the relay holds them otherwise.
+	DelayedDelivery() bool
+	// Close flushes the producers the broker created and closes its connection, consumers are
+	// closed by their owners.
+	Close()
+}
+
+// BrokerConfig selects the broker, only the config of the selected one is read.
+type BrokerConfig struct {
+	// Kind is pulsar, kafka or memory, pulsar when empty.
+	Kind string
+	// Subscription names the consumers' subscription or consumer group, when set it overrides
+	// the broker configs.
+	Subscription string
+	Pulsar       queue.PulsarConfig
+	Kafka        queue.KafkaConfig
+}
+
+func NewBroker(ctx context.Context, cfg BrokerConfig) (Broker, error) {
+	switch cfg.Kind {
+	case "", BrokerPulsar:
+		if cfg.Subscription != "" {
+			cfg.Pulsar.SubscriptionName = cfg.Subscription
+		}
+		return NewPulsarBroker(ctx, queue.NewPulsar(&cfg.Pulsar), cfg.Pulsar.Producer.Deduplication)
+	case BrokerKafka:
+		if cfg.Subscription != "" {
+			cfg.Kafka.GroupID = cfg.Subscription
+		}
+		return NewKafkaBroker(queue.NewKafka(&cfg.Kafka)), nil
+	case BrokerMemory:
+		return NewMemoryBroker(), nil
+	default:
+		return nil, fmt.Errorf("unknown broker %q, expected pulsar, kafka or memory", cfg.Kind)
+	}
+}
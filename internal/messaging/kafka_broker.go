@@ -0,0 +1,54 @@
+package messaging
+
+import (
+	"context"
+	"log/slog"
+	"sync"
+
+	"github.com/segmentio/kafka-go"
+	"github.com/yaninyzwitty/cqrs-eccomerce-service/internal/queue"
+)
+
+type KafkaBroker struct {
+	queue   queue.KafkaMethods
+	mu      sync.Mutex
+	writers []*kafka.Writer
+}
+
+func NewKafkaBroker(methods queue.KafkaMethods) *KafkaBroker {
+	return &KafkaBroker{queue: methods}
+}
+
+func (b *KafkaBroker) NewProducer(ctx context.Context, topic string) (MessageProducer, error) {
+	writer, err := b.queue.CreateKafkaWriter(ctx, topic)
+	if err != nil {
+		return nil, err
+	}
+	b.mu.Lock()
+	b.writers = append(b.writers, writer)
+	b.mu.Unlock()
+	return NewKafkaProducer(writer), nil
+}
+
+func (b *KafkaBroker) NewConsumer(ctx context.Context, topic string) (Consumer, error) {
+	reader, err := b.queue.CreateKafkaReader(ctx, topic)
+	if err != nil {
+		return nil, err
+	}
+	return NewKafkaConsumer(reader), nil
+}
+
+func (b *KafkaBroker) DelayedDelivery() bool {
+	return false
+}
+
+// Close flushes the writers, consumers are closed by their owners.
+func (b *KafkaBroker) Close() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	for _, writer := range b.writers {
+		if err := writer.Close(); err != nil {
+			slog.Error("failed to close kafka writer", "error", err)
+		}
+	}
+}
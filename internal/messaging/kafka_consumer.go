@@ -0,0 +1,97 @@
+package messaging
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/segmentio/kafka-go"
+)
+
+// kafkaRedeliveryDelay is how long a nacked message waits before it is received again.
+const kafkaRedeliveryDelay = 5 * time.Second
+
+// KafkaConsumer reads a topic as a member of a consumer group. Kafka only commits offsets, so a
+// nacked message is held and received again before anything newer, a later ack must not commit
+// past it.
+type KafkaConsumer struct {
+	reader *kafka.Reader
+	mu     sync.Mutex
+	nacked []kafka.Message
+}
+
+func NewKafkaConsumer(reader *kafka.Reader) *KafkaConsumer {
+	return &KafkaConsumer{reader: reader}
+}
+
+func (c *KafkaConsumer) Receive(ctx context.Context) (Message, error) {
+	c.mu.Lock()
+	if len(c.nacked) > 0 {
+		msg := c.nacked[0]
+		c.nacked = c.nacked[1:]
+		c.mu.Unlock()
+
+		select {
+		case <-time.After(kafkaRedeliveryDelay):
+			return kafkaMessage{msg: msg}, nil
+		case <-ctx.Done():
+			c.Nack(kafkaMessage{msg: msg})
+			return nil, ctx.Err()
+		}
+	}
+	c.mu.Unlock()
+
+	msg, err := c.reader.FetchMessage(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return kafkaMessage{msg: msg}, nil
+}
+
+func (c *KafkaConsumer) Ack(msg Message) error {
+	m, ok := msg.(kafkaMessage)
+	if !ok {
+		return fmt.Errorf("message %s was not received from kafka", msg.ID())
+	}
+	return c.reader.CommitMessages(context.Background(), m.msg)
+}
+
+func (c *KafkaConsumer) Nack(msg Message) {
+	m, ok := msg.(kafkaMessage)
+	if !ok {
+		return
+	}
+	c.mu.Lock()
+	c.nacked = append(c.nacked, m.msg)
+	c.mu.Unlock()
+}
+
+func (c *KafkaConsumer) Close() {
+	if err := c.reader.Close(); err != nil {
+		slog.Error("failed to close kafka reader", "error", err)
+	}
+}
+
+type kafkaMessage struct {
+	msg kafka.Message
+}
+
+func (m kafkaMessage) ID() string {
+	return m.msg.Topic + ":" + strconv.Itoa(m.msg.Partition) + ":" + strconv.FormatInt(m.msg.Offset, 10)
+}
+
+func (m kafkaMessage) Topic() string          { return m.msg.Topic }
+func (m kafkaMessage) Key() string            { return string(m.msg.Key) }
+func (m kafkaMessage) Payload() []byte        { return m.msg.Value }
+func (m kafkaMessage) PublishTime() time.Time { return m.msg.Time }
+
+func (m kafkaMessage) Properties() map[string]string {
+	properties := make(map[string]string, len(m.msg.Headers))
+	for _, header := range m.msg.Headers {
+		properties[header.Key] = string(header.Value)
+	}
+	return properties
+}
@@ -0,0 +1,40 @@
+package messaging
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+
+	"github.com/segmentio/kafka-go"
+	"github.com/yaninyzwitty/cqrs-eccomerce-service/internal/events"
+)
+
+type KafkaProducer struct {
+	writer *kafka.Writer
+}
+
+func NewKafkaProducer(writer *kafka.Writer) *KafkaProducer {
+	return &KafkaProducer{writer: writer}
+}
+
+// Publish writes the envelope as record headers, WriteMessages returns once the brokers acked.
+func (p *KafkaProducer) Publish(ctx context.Context, envelope events.Envelope, key string, payload []byte) error {
+	properties := envelope.Properties()
+	headers := make([]kafka.Header, 0, len(properties))
+	for name, value := range properties {
+		headers = append(headers, kafka.Header{Key: name, Value: []byte(value)})
+	}
+
+	err := p.writer.WriteMessages(ctx, kafka.Message{
+		Key:     []byte(key),
+		Value:   payload,
+		Headers: headers,
+		Time:    envelope.OccurredAt,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to publish message: %w", err)
+	}
+
+	slog.Info("Message sent to Kafka", "key", key, "eventID", envelope.EventID)
+	return nil
+}
@@ -0,0 +1,185 @@
+package messaging
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/yaninyzwitty/cqrs-eccomerce-service/internal/events"
+)
+
+// defaultMemorySubscription is the subscription every in-memory consumer shares.
+const defaultMemorySubscription = "memory"
+
+// memoryRedeliveryDelay is how long a nacked message waits before it is received again.
+const memoryRedeliveryDelay = time.Second
+
+// MemoryBroker keeps topics in process for tests, producers and consumers must share the broker.
+// Messages are kept for the life of the broker, a consumer of a topic receives everything
+// published to it.
+type MemoryBroker struct {
+	mu     sync.Mutex
+	topics map[string]*memoryTopic
+}
+
+type memoryTopic struct {
+	messages      []memoryMessage
+	subscriptions map[string]*memorySubscription
+}
+
+func NewMemoryBroker() *MemoryBroker {
+	return &MemoryBroker{topics: make(map[string]*memoryTopic)}
+}
+
+func (b *MemoryBroker) NewProducer(ctx context.Context, topic string) (MessageProducer, error) {
+	return &memoryProducer{broker: b, topic: topic}, nil
+}
+
+func (b *MemoryBroker) NewConsumer(ctx context.Context, topic string) (Consumer, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	t := b.topicLocked(topic)
+	subscription, ok := t.subscriptions[defaultMemorySubscription]
+	if !ok {
+		subscription = &memorySubscription{
+			pending: append([]memoryMessage(nil), t.messages...),
+			ready:   make(chan struct{}, 1),
+		}
+		t.subscriptions[defaultMemorySubscription] = subscription
+		subscription.signal()
+	}
+	return subscription, nil
+}
+
+func (b *MemoryBroker) DelayedDelivery() bool {
+	return false
+}
+
+func (b *MemoryBroker) Close() {}
+
+func (b *MemoryBroker) publish(msg memoryMessage) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	t := b.topicLocked(msg.topic)
+	msg.id = msg.topic + ":" + strconv.Itoa(len(t.messages))
+	t.messages = append(t.messages, msg)
+	for _, subscription := range t.subscriptions {
+		subscription.push(msg)
+	}
+}
+
+func (b *MemoryBroker) topicLocked(name string) *memoryTopic {
+	t, ok := b.topics[name]
+	if !ok {
+		t = &memoryTopic{subscriptions: make(map[string]*memorySubscription)}
+		b.topics[name] = t
+	}
+	return t
+}
+
+type memoryProducer struct {
+	broker *MemoryBroker
+	topic  string
+}
+
+func (p *memoryProducer) Publish(ctx context.Context, envelope events.Envelope, key string, payload []byte) error {
+	if err := ctx.Err(); err != nil {
+		return fmt.Errorf("failed to publish message: %w", err)
+	}
+
+	p.broker.publish(memoryMessage{
+		topic:       p.topic,
+		key:         key,
+		payload:     append([]byte(nil), payload...),
+		properties:  envelope.Properties(),
+		publishTime: time.Now(),
+	})
+
+	slog.Info("Message published in memory", "key", key, "eventID", envelope.EventID)
+	return nil
+}
+
+// memorySubscription hands each message to one of its consumers, nacked messages go back to the
+// front of the queue after memoryRedeliveryDelay.
+type memorySubscription struct {
+	mu      sync.Mutex
+	pending []memoryMessage
+	ready   chan struct{}
+}
+
+func (s *memorySubscription) push(msg memoryMessage) {
+	s.mu.Lock()
+	s.pending = append(s.pending, msg)
+	s.mu.Unlock()
+	s.signal()
+}
+
+func (s *memorySubscription) signal() {
+	select {
+	case s.ready <- struct{}{}:
+	default:
+	}
+}
+
+func (s *memorySubscription) Receive(ctx context.Context) (Message, error) {
+	for {
+		s.mu.Lock()
+		if len(s.pending) > 0 {
+			msg := s.pending[0]
+			s.pending = s.pending[1:]
+			more := len(s.pending) > 0
+			s.mu.Unlock()
+			if more {
+				s.signal()
+			}
+			return msg, nil
+		}
+		s.mu.Unlock()
+
+		select {
+		case <-s.ready:
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		}
+	}
+}
+
+func (s *memorySubscription) Ack(msg Message) error {
+	return nil
+}
+
+func (s *memorySubscription) Nack(msg Message) {
+	m, ok := msg.(memoryMessage)
+	if !ok {
+		return
+	}
+	time.AfterFunc(memoryRedeliveryDelay, func() {
+		s.mu.Lock()
+		s.pending = append([]memoryMessage{m}, s.pending...)
+		s.mu.Unlock()
+		s.signal()
+	})
+}
+
+func (s *memorySubscription) Close() {}
+
+type memoryMessage struct {
+	id          string
+	topic       string
+	key         string
+	payload     []byte
+	properties  map[string]string
+	publishTime time.Time
+}
+
+func (m memoryMessage) ID() string                    { return m.id }
+func (m memoryMessage) Topic() string                 { return m.topic }
+func (m memoryMessage) Key() string                   { return m.key }
+func (m memoryMessage) Payload() []byte               { return m.payload }
+func (m memoryMessage) Properties() map[string]string { return m.properties }
+func (m memoryMessage) PublishTime() time.Time        { return m.publishTime }
@@ -0,0 +1,74 @@
+package messaging
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/yaninyzwitty/cqrs-eccomerce-service/internal/events"
+)
+
+func TestMemoryBrokerDeliversPublishedMessages(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	broker := NewMemoryBroker()
+	producer, err := broker.NewProducer(ctx, "products")
+	if err != nil {
+		t.Fatalf("NewProducer: %v", err)
+	}
+
+	// published before the consumer exists, the subscription starts from the topic history
+	envelope := events.Envelope{EventID: "first", EventType: events.ProductCreated.Name()}
+	if err := producer.Publish(ctx, envelope, "1", []byte(`{"id":"1"}`)); err != nil {
+		t.Fatalf("Publish: %v", err)
+	}
+
+	consumer, err := broker.NewConsumer(ctx, "products")
+	if err != nil {
+		t.Fatalf("NewConsumer: %v", err)
+	}
+	defer consumer.Close()
+
+	if err := producer.Publish(ctx, events.Envelope{EventID: "second"}, "2", []byte(`{"id":"2"}`)); err != nil {
+		t.Fatalf("Publish: %v", err)
+	}
+
+	first, err := consumer.Receive(ctx)
+	if err != nil {
+		t.Fatalf("Receive: %v", err)
+	}
+	if first.Key() != "1" || string(first.Payload()) != `{"id":"1"}` || first.Topic() != "products" {
+		t.Fatalf("received %s %q on %s, want the first message", first.Key(), first.Payload(), first.Topic())
+	}
+	if got := first.Properties()[events.PropertyEventType]; got != events.ProductCreated.Name() {
+		t.Fatalf("event type property = %q, want %q", got, events.ProductCreated.Name())
+	}
+
+	// a nacked message is received again once the redelivery delay passed
+	consumer.Nack(first)
+	second, err := consumer.Receive(ctx)
+	if err != nil {
+		t.Fatalf("Receive: %v", err)
+	}
+	if second.Key() != "2" {
+		t.Fatalf("received key %s, want 2", second.Key())
+	}
+	if err := consumer.Ack(second); err != nil {
+		t.Fatalf("Ack: %v", err)
+	}
+
+	redelivered, err := consumer.Receive(ctx)
+	if err != nil {
+		t.Fatalf("Receive: %v", err)
+	}
+	if redelivered.ID() != first.ID() {
+		t.Fatalf("redelivered %s, want %s", redelivered.ID(), first.ID())
+	}
+
+	waitCtx, cancelWait := context.WithTimeout(ctx, 50*time.Millisecond)
+	defer cancelWait()
+	if msg, err := consumer.Receive(waitCtx); err == nil {
+		t.Fatalf("received %s after the topic was drained", msg.ID())
+	}
+}
@@ -0,0 +1,60 @@
+package messaging
+
+import (
+	"context"
+	"sync"
+
+	"github.com/apache/pulsar-client-go/pulsar"
+	"github.com/yaninyzwitty/cqrs-eccomerce-service/internal/queue"
+)
+
+// PulsarBroker shares one client between its producers and consumers.
+type PulsarBroker struct {
+	queue  queue.PulsarMethods
+	client pulsar.Client
+	// deduplication has producers send the sequence ids the broker dedups on
+	deduplication bool
+	mu            sync.Mutex
+	producers     []pulsar.Producer
+}
+
+func NewPulsarBroker(ctx context.Context, methods queue.PulsarMethods, deduplication bool) (*PulsarBroker, error) {
+	client, err := methods.CreatePulsarConnection(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return &PulsarBroker{queue: methods, client: client, deduplication: deduplication}, nil
+}
+
+func (b *PulsarBroker) NewProducer(ctx context.Context, topic string) (MessageProducer, error) {
+	producer, err := b.queue.CreatePulsarProducer(ctx, b.client, topic)
+	if err != nil {
+		return nil, err
+	}
+	b.mu.Lock()
+	b.producers = append(b.producers, producer)
+	b.mu.Unlock()
+	return NewPulsarProducer(producer, b.deduplication), nil
+}
+
+func (b *PulsarBroker) NewConsumer(ctx context.Context, topic string) (Consumer, error) {
+	consumer, err := b.queue.CreatePulsarConsumer(ctx, b.client, topic)
+	if err != nil {
+		return nil, err
+	}
+	return NewPulsarConsumer(consumer), nil
+}
+
+func (b *PulsarBroker) DelayedDelivery() bool {
+	return true
+}
+
+// Close flushes the producers and closes the client along with its consumers.
+func (b *PulsarBroker) Close() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	for _, producer := range b.producers {
+		producer.Close()
+	}
+	b.client.Close()
+}
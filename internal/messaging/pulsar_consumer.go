@@ -0,0 +1,64 @@
+package messaging
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/apache/pulsar-client-go/pulsar"
+)
+
+type PulsarConsumer struct {
+	consumer pulsar.Consumer
+}
+
+func NewPulsarConsumer(consumer pulsar.Consumer) *PulsarConsumer {
+	return &PulsarConsumer{consumer: consumer}
+}
+
+// Pulsar returns the underlying consumer for Pulsar only features such as seeking.
+func (c *PulsarConsumer) Pulsar() pulsar.Consumer {
+	return c.consumer
+}
+
+func (c *PulsarConsumer) Receive(ctx context.Context) (Message, error) {
+	msg, err := c.consumer.Receive(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return NewPulsarMessage(msg), nil
+}
+
+func (c *PulsarConsumer) Ack(msg Message) error {
+	m, ok := msg.(pulsarMessage)
+	if !ok {
+		return fmt.Errorf("message %s was not received from pulsar", msg.ID())
+	}
+	return c.consumer.Ack(m.msg)
+}
+
+func (c *PulsarConsumer) Nack(msg Message) {
+	if m, ok := msg.(pulsarMessage); ok {
+		c.consumer.Nack(m.msg)
+	}
+}
+
+func (c *PulsarConsumer) Close() {
+	c.consumer.Close()
+}
+
+type pulsarMessage struct {
+	msg pulsar.Message
+}
+
+// NewPulsarMessage wraps a message received straight from a Pulsar consumer.
+func NewPulsarMessage(msg pulsar.Message) Message {
+	return pulsarMessage{msg: msg}
+}
+
+func (m pulsarMessage) ID() string                    { return m.msg.ID().String() }
+func (m pulsarMessage) Topic() string                 { return m.msg.Topic() }
+func (m pulsarMessage) Key() string                   { return m.msg.Key() }
+func (m pulsarMessage) Payload() []byte               { return m.msg.Payload() }
+func (m pulsarMessage) Properties() map[string]string { return m.msg.Properties() }
+func (m pulsarMessage) PublishTime() time.Time        { return m.msg.PublishTime() }
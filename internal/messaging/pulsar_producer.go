@@ -7,31 +7,45 @@ import (
 	"log/slog"
 
 	"github.com/apache/pulsar-client-go/pulsar"
+	"github.com/yaninyzwitty/cqrs-eccomerce-service/internal/events"
 )
 
 type MessageProducer interface {
-	Publish(ctx context.Context, eventType string, key string, payload []byte) error
+	Publish(ctx context.Context, envelope events.Envelope, key string, payload []byte) error
 }
 
 type PulsarProducer struct {
-	producer pulsar.Producer
+	producer      pulsar.Producer
+	deduplication bool
 }
 
-func NewPulsarProducer(producer pulsar.Producer) *PulsarProducer {
-	return &PulsarProducer{producer: producer}
+func NewPulsarProducer(producer pulsar.Producer, deduplication bool) *PulsarProducer {
+	return &PulsarProducer{producer: producer, deduplication: deduplication}
 }
 
-func (p *PulsarProducer) Publish(ctx context.Context, eventType string, key string, payload []byte) error {
+func (p *PulsarProducer) Publish(ctx context.Context, envelope events.Envelope, key string, payload []byte) error {
 	if p.producer == nil {
 		return fmt.Errorf("producer is nil, cannot send messages")
 	}
 
+	message := &pulsar.ProducerMessage{
+		Key:        key,
+		Payload:    payload,
+		Properties: envelope.Properties(),
+		EventTime:  envelope.OccurredAt,
+		// only shared subscriptions hold delayed messages back, others receive them right away
+		DeliverAt: envelope.DeliverAt,
+	}
+	if p.deduplication {
+		// OccurredAt is the time of the outbox row id, so a row sent again carries the sequence id
+		// the broker already stored for it
+		sequenceID := envelope.OccurredAt.UnixNano()
+		message.SequenceID = &sequenceID
+	}
+
 	messageChan := make(chan error, 1)
 
-	p.producer.SendAsync(ctx, &pulsar.ProducerMessage{
-		Key:     key,
-		Payload: payload,
-	}, func(_ pulsar.MessageID, _ *pulsar.ProducerMessage, err error) {
+	p.producer.SendAsync(ctx, message, func(_ pulsar.MessageID, _ *pulsar.ProducerMessage, err error) {
 		messageChan <- err
 	})
 
@@ -44,6 +58,6 @@ func (p *PulsarProducer) Publish(ctx context.Context, eventType string, key stri
 		return fmt.Errorf("context canceled while publishing message")
 	}
 
-	slog.Info("Message sent to Pulsar", "key", key)
+	slog.Info("Message sent to Pulsar", "key", key, "eventID", envelope.EventID)
 	return nil
 }
@@ -0,0 +1,132 @@
+package messaging
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/yaninyzwitty/cqrs-eccomerce-service/internal/events"
+)
+
+// Routes picks the topic of an event: its event type route, else its aggregate type route,
+// else the default topic. Every event of an aggregate must land on one topic to be consumed in
+// order, so event type routes may only send an event where the rest of its aggregate goes.
+type Routes struct {
+	Default        string
+	EventTypes     map[string]string
+	AggregateTypes map[string]string
+}
+
+func (r Routes) Topic(envelope events.Envelope) string {
+	if topic, ok := r.EventTypes[envelope.EventType]; ok {
+		return topic
+	}
+	if topic, ok := r.AggregateTypes[envelope.AggregateType]; ok {
+		return topic
+	}
+	return r.Default
+}
+
+// Topics lists every topic an event can be routed to, consumers subscribe to all of them.
+func (r Routes) Topics() []string {
+	topics := []string{r.Default}
+	for _, routes := range []map[string]string{r.EventTypes, r.AggregateTypes} {
+		for _, topic := range routes {
+			if !slices.Contains(topics, topic) {
+				topics = append(topics, topic)
+			}
+		}
+	}
+	sort.Strings(topics[1:])
+	return topics
+}
+
+// validate fails on routes of events or aggregates the registry does not know, a typo would
+// otherwise silently send events to the default topic, and on event type routes that split the
+// events of an aggregate across topics.
+func (r Routes) validate(registry *events.Registry) error {
+	if r.Default == "" {
+		return fmt.Errorf("no default topic configured")
+	}
+
+	var eventTypes []string
+	for eventType, topic := range r.EventTypes {
+		if topic == "" {
+			return fmt.Errorf("event type %s is routed to an empty topic", eventType)
+		}
+		eventTypes = append(eventTypes, eventType)
+	}
+	if err := registry.Require(eventTypes...); err != nil {
+		return fmt.Errorf("invalid event type route: %w", err)
+	}
+
+	known := registry.AggregateTypes()
+	var unknown []string
+	for aggregateType, topic := range r.AggregateTypes {
+		if topic == "" {
+			return fmt.Errorf("aggregate type %s is routed to an empty topic", aggregateType)
+		}
+		if !slices.Contains(known, aggregateType) {
+			unknown = append(unknown, aggregateType)
+		}
+	}
+	if len(unknown) > 0 {
+		sort.Strings(unknown)
+		return fmt.Errorf("invalid aggregate type route: aggregates not registered: %s", strings.Join(unknown, ", "))
+	}
+
+	topics := make(map[string]string)
+	for _, definition := range registry.Definitions() {
+		aggregateType := definition.AggregateType()
+		topic := r.Topic(events.Envelope{EventType: definition.Name(), AggregateType: aggregateType})
+		if other, ok := topics[aggregateType]; ok && other != topic {
+			return fmt.Errorf("invalid event type route: %s events would be published to both %s and %s, route the aggregate type instead", aggregateType, other, topic)
+		}
+		topics[aggregateType] = topic
+	}
+	return nil
+}
+
+// RoutingProducer publishes every event to the topic its routes pick, creating the producer of
+// a topic when the first event is routed to it.
+type RoutingProducer struct {
+	broker    Broker
+	routes    Routes
+	mu        sync.Mutex
+	producers map[string]MessageProducer
+}
+
+func NewRoutingProducer(broker Broker, routes Routes, registry *events.Registry) (*RoutingProducer, error) {
+	if err := routes.validate(registry); err != nil {
+		return nil, err
+	}
+	return &RoutingProducer{broker: broker, routes: routes, producers: make(map[string]MessageProducer)}, nil
+}
+
+func (p *RoutingProducer) Publish(ctx context.Context, envelope events.Envelope, key string, payload []byte) error {
+	producer, err := p.producer(ctx, p.routes.Topic(envelope))
+	if err != nil {
+		return err
+	}
+	return producer.Publish(ctx, envelope, key, payload)
+}
+
+// producer holds the lock while creating a producer so concurrent lanes never create two for
+// the same topic, a failed creation is retried with the next event.
+func (p *RoutingProducer) producer(ctx context.Context, topic string) (MessageProducer, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if producer, ok := p.producers[topic]; ok {
+		return producer, nil
+	}
+	producer, err := p.broker.NewProducer(ctx, topic)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create producer for topic %s: %w", topic, err)
+	}
+	p.producers[topic] = producer
+	return producer, nil
+}
@@ -0,0 +1,29 @@
+package processor
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+)
+
+var (
+	oldestPendingMessageAge = promauto.NewGauge(prometheus.GaugeOpts{
+		Name: "outbox_oldest_pending_message_age_seconds",
+		Help: "Age of the oldest outbox message still waiting to be published after the last relay pass.",
+	})
+	pendingMessages = promauto.NewGauge(prometheus.GaugeOpts{
+		Name: "outbox_pending_messages",
+		Help: "Outbox messages still waiting to be published after the last relay pass.",
+	})
+	pendingBuckets = promauto.NewGauge(prometheus.GaugeOpts{
+		Name: "outbox_pending_buckets",
+		Help: "Outbox buckets swept by the last relay pass.",
+	})
+	relayFailures = promauto.NewCounter(prometheus.CounterOpts{
+		Name: "outbox_relay_failures_total",
+		Help: "Outbox messages that failed to be converted or published.",
+	})
+	deadLetteredMessages = promauto.NewCounter(prometheus.CounterOpts{
+		Name: "outbox_dead_lettered_messages_total",
+		Help: "Outbox messages moved to the dead letter table.",
+	})
+)
@@ -0,0 +1,178 @@
+package processor
+
+import (
+	"context"
+	"log/slog"
+	"sync"
+	"time"
+
+	"github.com/yaninyzwitty/cqrs-eccomerce-service/internal/events"
+	"github.com/yaninyzwitty/cqrs-eccomerce-service/internal/repository"
+)
+
+// deleteBatchSize caps the published rows removed from the outbox in one batch.
+const deleteBatchSize = 100
+
+type preparedMessage struct {
+	// key is the aggregate of the row, published as the message key
+	key      string
+	message  repository.OutboxMessage
+	envelope events.Envelope
+	payload  []byte
+}
+
+// lane holds the rows of one aggregate in outbox order, each is published only after the
+// previous one was acked so consumers see them in order. With deduplication a single lane holds
+// every row.
+type lane struct {
+	key      string
+	messages []preparedMessage
+}
+
+// relayPass tracks the rows one pass leaves in the outbox.
+type relayPass struct {
+	now time.Time
+
+	mu      sync.Mutex
+	pending map[string]int
+	oldest  time.Time
+}
+
+func newRelayPass(now time.Time) *relayPass {
+	return &relayPass{now: now, pending: make(map[string]int)}
+}
+
+func (p *relayPass) keep(messages ...repository.OutboxMessage) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	for _, message := range messages {
+		p.pending[message.Bucket]++
+		if createdAt := message.Id.Time(); p.oldest.IsZero() || createdAt.Before(p.oldest) {
+			p.oldest = createdAt
+		}
+	}
+}
+
+// hold keeps a scheduled row that is not due yet, it does not count towards the oldest pending age.
+func (p *relayPass) hold(message repository.OutboxMessage) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	p.pending[message.Bucket]++
+}
+
+func (p *relayPass) remaining() int {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	var remaining int
+	for _, count := range p.pending {
+		remaining += count
+	}
+	return remaining
+}
+
+// relayLanes publishes up to maxInFlight lanes at once and deletes the acked rows in batches.
+func (pm *ProcessMessage) relayLanes(ctx context.Context, pass *relayPass, lanes []*lane) {
+	acked := make(chan repository.OutboxMessage, pm.maxInFlight)
+	deleted := make(chan struct{})
+	go func() {
+		defer close(deleted)
+		pm.deleteAcked(ctx, pass, acked)
+	}()
+
+	inFlight := make(chan struct{}, pm.maxInFlight)
+	var wg sync.WaitGroup
+	for _, l := range lanes {
+		inFlight <- struct{}{}
+		wg.Add(1)
+		go func(l *lane) {
+			defer func() {
+				<-inFlight
+				wg.Done()
+			}()
+			pm.relayLane(ctx, pass, l, acked)
+		}(l)
+	}
+
+	wg.Wait()
+	close(acked)
+	<-deleted
+}
+
+// relayLane stops at the first row that fails, the rest of the lane waits for its retry.
+func (pm *ProcessMessage) relayLane(ctx context.Context, pass *relayPass, l *lane, acked chan<- repository.OutboxMessage) {
+	for i, prepared := range l.messages {
+		if ctx.Err() != nil {
+			pass.keep(messagesOf(l.messages[i:])...)
+			return
+		}
+
+		if err := pm.producer.Publish(ctx, prepared.envelope, prepared.key, prepared.payload); err != nil {
+			// the pass ran out of lease time, the row was not at fault
+			if ctx.Err() != nil {
+				pass.keep(messagesOf(l.messages[i:])...)
+				return
+			}
+			slog.Error("Failed to publish message", "error", err, "messageID", prepared.message.Id)
+			rest := l.messages[i+1:]
+			if pm.recordPublishFailure(ctx, pass, prepared.message, err) {
+				rest = pm.deadLetterAggregate(ctx, pass, prepared.key, rest)
+			}
+			pass.keep(messagesOf(rest)...)
+			return
+		}
+		acked <- prepared.message
+	}
+}
+
+// deleteAcked removes published rows from the outbox. The events are already out, so the
+// deletes run even when the pass ran out of lease time.
+func (pm *ProcessMessage) deleteAcked(ctx context.Context, pass *relayPass, acked <-chan repository.OutboxMessage) {
+	ctx = context.WithoutCancel(ctx)
+	batch := make([]repository.OutboxMessage, 0, deleteBatchSize)
+
+	flush := func() {
+		if len(batch) == 0 {
+			return
+		}
+		// a row left behind is only published again on the next pass
+		if err := pm.repo.DeleteMessages(ctx, batch); err != nil {
+			slog.Error("Failed to delete messages", "error", err, "count", len(batch))
+			pass.keep(batch...)
+		}
+		batch = batch[:0]
+	}
+
+	for message := range acked {
+		batch = append(batch, message)
+		if len(batch) == deleteBatchSize {
+			flush()
+		}
+	}
+	flush()
+}
+
+// deadLetterAggregate moves the rows of an aggregate whose earlier row was dead lettered along with
+// it, so they do not overtake it and are requeued together. It returns the rows of other aggregates.
+func (pm *ProcessMessage) deadLetterAggregate(ctx context.Context, pass *relayPass, key string, rest []preparedMessage) []preparedMessage {
+	others := rest[:0:0]
+	for _, prepared := range rest {
+		if prepared.key != key {
+			others = append(others, prepared)
+			continue
+		}
+		prepared.message.LastError = "an earlier event of the aggregate was dead lettered"
+		pm.deadLetter(ctx, pass, prepared.message)
+	}
+	return others
+}
+
+func messagesOf(prepared []preparedMessage) []repository.OutboxMessage {
+	messages := make([]repository.OutboxMessage, len(prepared))
+	for i, p := range prepared {
+		messages[i] = p.message
+	}
+	return messages
+}
@@ -2,8 +2,10 @@ package processor
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"log/slog"
+	"sort"
 	"time"
 
 	"github.com/yaninyzwitty/cqrs-eccomerce-service/internal/events"
@@ -11,51 +13,291 @@ import (
 	"github.com/yaninyzwitty/cqrs-eccomerce-service/internal/repository"
 )
 
+// bucketGracePeriod keeps a past bucket indexed for a while after its day ended, so rows from
+// writers with a lagging clock or batches in flight at midnight are still swept.
+const bucketGracePeriod = time.Hour
+
+// defaultMaxInFlight applies when the config does not set relay.max_in_flight.
+const defaultMaxInFlight = 64
+
+// dedupSettleDelay leaves the newest rows to the next pass when the broker dedups, a row committed
+// late with an older id would otherwise get a sequence id below one already sent and be dropped.
+const dedupSettleDelay = 5 * time.Second
+
+// orderedLane is the lane of every row when the broker dedups.
+const orderedLane = "outbox"
+
+// errUnknownEventType marks rows no handler can turn into an event, they are quarantined
+// in the dead letter table instead of being retried.
+var errUnknownEventType = errors.New("unknown event type")
+
+// Options tunes how the relay publishes the outbox.
+type Options struct {
+	// Name is the producer recorded in the envelope of every published event.
+	Name     string
+	Encoding events.Encoding
+	Retry    RetryPolicy
+	// MaxInFlight caps the messages waiting for a broker ack at once.
+	MaxInFlight int
+	// DelayedDelivery hands scheduled events to the broker right away to deliver them on time,
+	// otherwise the relay holds them in the outbox until they are due.
+	DelayedDelivery bool
+	// Deduplication publishes the rows one after another in outbox order, the broker drops any
+	// message whose sequence id is not above the last one it stored.
+	Deduplication bool
+}
+
 type ProcessMessage struct {
-	producer messaging.MessageProducer
-	repo     repository.OutboxRepository
+	producer    messaging.MessageProducer
+	repo        repository.OutboxRepository
+	registry    *events.Registry
+	name        string
+	encoding    events.Encoding
+	retry       RetryPolicy
+	maxInFlight int
+	delayed     bool
+	dedup       bool
 }
 
-func NewProcessMessage(producer messaging.MessageProducer, repo repository.OutboxRepository) *ProcessMessage {
-	return &ProcessMessage{producer: producer, repo: repo}
+func NewProcessMessage(producer messaging.MessageProducer, repo repository.OutboxRepository, registry *events.Registry, opts Options) *ProcessMessage {
+	maxInFlight := opts.MaxInFlight
+	if maxInFlight <= 0 {
+		maxInFlight = defaultMaxInFlight
+	}
+
+	return &ProcessMessage{
+		producer:    producer,
+		repo:        repo,
+		registry:    registry,
+		name:        opts.Name,
+		encoding:    opts.Encoding,
+		retry:       opts.Retry.withDefaults(),
+		maxInFlight: maxInFlight,
+		delayed:     opts.DelayedDelivery,
+		dedup:       opts.Deduplication,
+	}
 }
 
+// ProcessMessages publishes the pending rows of every indexed bucket and drops past buckets from
+// the index once they are empty. Rows of different aggregates are published concurrently, rows of
+// the same aggregate in the order they were written.
 func (pm *ProcessMessage) ProcessMessages(ctx context.Context) error {
-	bucket := time.Now().Format("2006-01-02")
-	messages, err := pm.repo.FetchMessages(ctx, bucket)
+	now := time.Now()
+	buckets, err := pm.pendingBuckets(ctx, now)
 	if err != nil {
-		return fmt.Errorf("error fetching messages: %w", err)
+		return fmt.Errorf("error fetching buckets: %w", err)
 	}
 
-	for _, message := range messages {
-		payload, err := pm.handleEvent(message)
-		if err != nil {
-			slog.Error("Failed to process event", "error", err, "eventType", message.EventType)
-			continue
+	pass := newRelayPass(now)
+	lanes, err := pm.planLanes(ctx, pass, buckets)
+	if err != nil {
+		return err
+	}
+	pm.relayLanes(ctx, pass, lanes)
+
+	// stop before the relay lease lapses, another replica may own the outbox by then
+	if err := ctx.Err(); err != nil {
+		return fmt.Errorf("relay pass interrupted: %w", err)
+	}
+
+	for _, bucket := range buckets {
+		if pass.pending[bucket] == 0 {
+			pm.retireBucket(ctx, bucket, now)
 		}
+	}
+
+	remaining := pass.remaining()
+	pendingBuckets.Set(float64(len(buckets)))
+	pendingMessages.Set(float64(remaining))
+	if pass.oldest.IsZero() {
+		oldestPendingMessageAge.Set(0)
+	} else {
+		age := now.Sub(pass.oldest)
+		oldestPendingMessageAge.Set(age.Seconds())
+		slog.Warn("Outbox messages still pending", "count", remaining, "oldestAge", age)
+	}
+
+	return nil
+}
+
+// pendingBuckets returns the indexed buckets in date order, always including today's bucket
+// so rows written before the index existed are not missed.
+func (pm *ProcessMessage) pendingBuckets(ctx context.Context, now time.Time) ([]string, error) {
+	buckets, err := pm.repo.FetchBuckets(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	today := repository.OutboxBucket(now)
+	hasToday := false
+	for _, bucket := range buckets {
+		if bucket == today {
+			hasToday = true
+			break
+		}
+	}
+	if !hasToday {
+		buckets = append(buckets, today)
+	}
+
+	sort.Strings(buckets)
+	return buckets, nil
+}
+
+// planLanes reads the buckets oldest first and groups the rows that are due by aggregate. A row
+// waiting for its retry holds back the later rows of its aggregate. Scheduled rows are published
+// on their own lanes and never hold back their aggregate, they are not ordered with its other
+// events anyway. With deduplication every row, scheduled or not, waits for the rows before it.
+func (pm *ProcessMessage) planLanes(ctx context.Context, pass *relayPass, buckets []string) ([]*lane, error) {
+	var lanes []*lane
+	byKey := make(map[string]*lane)
+	blocked := make(map[string]bool)
 
-		key := fmt.Sprintf("%s:%v", message.EventType, message.Id)
-		if err := pm.producer.Publish(ctx, message.EventType, key, payload); err != nil {
-			slog.Error("Failed to send message to Pulsar", "error", err, "messageID", message.Id)
-			continue
+	for _, bucket := range buckets {
+		messages, err := pm.repo.FetchMessages(ctx, bucket)
+		if err != nil {
+			return nil, fmt.Errorf("error fetching messages: %w", err)
 		}
 
-		if err := pm.repo.DeleteMessage(ctx, message); err != nil {
-			slog.Error("Failed to delete message", "error", err, "messageID", message.Id)
+		for _, message := range messages {
+			due := !message.NextAttemptAt.After(pass.now)
+
+			envelope, payload, err := pm.handleEvent(message)
+			if err != nil {
+				if due {
+					slog.Error("Failed to process event", "error", err, "eventType", message.EventType)
+					pm.recordFailure(ctx, pass, message, err)
+				} else {
+					pass.keep(message)
+				}
+				continue
+			}
+
+			key := fmt.Sprintf("%s:%s", envelope.AggregateType, envelope.AggregateID)
+			prepared := preparedMessage{key: key, message: message, envelope: envelope, payload: payload}
+			if !message.DeliverAt.IsZero() && !pm.dedup {
+				switch {
+				case !pm.delayed && message.DeliverAt.After(pass.now):
+					pass.hold(message)
+				case !due:
+					pass.keep(message)
+				default:
+					lanes = append(lanes, &lane{key: key, messages: []preparedMessage{prepared}})
+				}
+				continue
+			}
+
+			laneKey := key
+			if pm.dedup {
+				laneKey = orderedLane
+				due = due && message.Id.Time().Before(pass.now.Add(-dedupSettleDelay))
+			}
+			if !due || blocked[laneKey] {
+				blocked[laneKey] = true
+				pass.keep(message)
+				continue
+			}
+
+			l, ok := byKey[laneKey]
+			if !ok {
+				l = &lane{key: laneKey}
+				byKey[laneKey] = l
+				lanes = append(lanes, l)
+			}
+			l.messages = append(l.messages, prepared)
 		}
 	}
 
-	return nil
+	return lanes, nil
+}
+
+// retireBucket removes an empty bucket from the index once no writer can add to it anymore.
+func (pm *ProcessMessage) retireBucket(ctx context.Context, bucket string, now time.Time) {
+	end, err := repository.OutboxBucketEnd(bucket)
+	if err != nil {
+		slog.Error("Failed to parse outbox bucket", "error", err, "bucket", bucket)
+		return
+	}
+	if now.Before(end.Add(bucketGracePeriod)) {
+		return
+	}
+
+	if err := pm.repo.DeleteBucket(ctx, bucket); err != nil {
+		slog.Error("Failed to delete outbox bucket", "error", err, "bucket", bucket)
+	}
+}
+
+// recordFailure schedules the next attempt of a row that could not be turned into an event with
+// exponential backoff, or moves it to the dead letter table once it is out of attempts.
+func (pm *ProcessMessage) recordFailure(ctx context.Context, pass *relayPass, message repository.OutboxMessage, cause error) {
+	relayFailures.Inc()
+	message.Attempts++
+	message.LastError = cause.Error()
+
+	if errors.Is(cause, errUnknownEventType) || message.Attempts >= pm.retry.MaxAttempts {
+		pm.deadLetter(ctx, pass, message)
+		return
+	}
+	pm.retryLater(ctx, pass, message)
+}
+
+// recordPublishFailure schedules the next attempt of a row the broker did not take, or moves it to
+// the dead letter table once it is out of attempts and reports so.
+func (pm *ProcessMessage) recordPublishFailure(ctx context.Context, pass *relayPass, message repository.OutboxMessage, cause error) bool {
+	relayFailures.Inc()
+	message.Attempts++
+	message.LastError = cause.Error()
+
+	if message.Attempts >= pm.retry.MaxAttempts {
+		return pm.deadLetter(ctx, pass, message)
+	}
+	pm.retryLater(ctx, pass, message)
+	return false
+}
+
+// deadLetter moves a row to the dead letter table, the row stays in the outbox when that fails.
+func (pm *ProcessMessage) deadLetter(ctx context.Context, pass *relayPass, message repository.OutboxMessage) bool {
+	if err := pm.repo.DeadLetter(ctx, message); err != nil {
+		slog.Error("Failed to dead letter message", "error", err, "messageID", message.Id)
+		pass.keep(message)
+		return false
+	}
+	deadLetteredMessages.Inc()
+	return true
 }
 
-func (pm *ProcessMessage) handleEvent(message repository.OutboxMessage) ([]byte, error) {
-	switch message.EventType {
-	case "category.created":
-		return events.HandleCategoryCreated(message.Payload)
-	case "product.created":
-		return events.HandleProductCreated(message.Payload)
-	default:
-		slog.Warn("Unknown event type, skipping", "eventType", message.EventType, "messageID", message.Id)
-		return nil, nil
+func (pm *ProcessMessage) retryLater(ctx context.Context, pass *relayPass, message repository.OutboxMessage) {
+	message.NextAttemptAt = pass.now.Add(pm.retry.Backoff(message.Attempts))
+	if err := pm.repo.RecordFailure(ctx, message); err != nil {
+		slog.Error("Failed to record message failure", "error", err, "messageID", message.Id)
+	}
+	pass.keep(message)
+}
+
+// handleEvent turns an outbox row into the payload published for it and its envelope.
+func (pm *ProcessMessage) handleEvent(message repository.OutboxMessage) (events.Envelope, []byte, error) {
+	definition, ok := pm.registry.Lookup(message.EventType)
+	if !ok {
+		return events.Envelope{}, nil, fmt.Errorf("%w: %q", errUnknownEventType, message.EventType)
+	}
+
+	transformed, err := definition.Transform(message.Payload, pm.encoding)
+	if err != nil {
+		return events.Envelope{}, nil, err
+	}
+
+	envelope := events.Envelope{
+		EventID:       message.Id.String(),
+		EventType:     definition.Name(),
+		SchemaVersion: definition.SchemaVersion(),
+		AggregateType: definition.AggregateType(),
+		AggregateID:   transformed.AggregateID,
+		OccurredAt:    message.Id.Time(),
+		Producer:      pm.name,
+		CorrelationID: message.CorrelationID,
+		CausationID:   message.CausationID,
+		ContentType:   pm.encoding.ContentType(),
+		DeliverAt:     message.DeliverAt,
 	}
+	return envelope, transformed.Payload, nil
 }
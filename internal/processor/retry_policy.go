@@ -0,0 +1,39 @@
+package processor
+
+import "time"
+
+const (
+	defaultMaxAttempts    = 10
+	defaultInitialBackoff = 4 * time.Second
+	defaultMaxBackoff     = 10 * time.Minute
+)
+
+// RetryPolicy decides when a failed outbox row is tried again and when the relay gives up on it.
+type RetryPolicy struct {
+	MaxAttempts    int
+	InitialBackoff time.Duration
+	MaxBackoff     time.Duration
+}
+
+func (p RetryPolicy) withDefaults() RetryPolicy {
+	if p.MaxAttempts <= 0 {
+		p.MaxAttempts = defaultMaxAttempts
+	}
+	if p.InitialBackoff <= 0 {
+		p.InitialBackoff = defaultInitialBackoff
+	}
+	if p.MaxBackoff < p.InitialBackoff {
+		p.MaxBackoff = max(defaultMaxBackoff, p.InitialBackoff)
+	}
+	return p
+}
+
+// Backoff is the wait before the next attempt of a row that already failed attempts times,
+// doubling from InitialBackoff up to MaxBackoff.
+func (p RetryPolicy) Backoff(attempts int) time.Duration {
+	backoff := p.InitialBackoff
+	for i := 1; i < attempts && backoff < p.MaxBackoff; i++ {
+		backoff *= 2
+	}
+	return min(backoff, p.MaxBackoff)
+}
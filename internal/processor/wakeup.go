@@ -0,0 +1,22 @@
+package processor
+
+// Wakeup tells the relay that outbox rows were written. Signals sent while a pass is running
+// are coalesced into a single extra pass.
+type Wakeup struct {
+	ch chan struct{}
+}
+
+func NewWakeup() *Wakeup {
+	return &Wakeup{ch: make(chan struct{}, 1)}
+}
+
+func (w *Wakeup) Notify() {
+	select {
+	case w.ch <- struct{}{}:
+	default:
+	}
+}
+
+func (w *Wakeup) C() <-chan struct{} {
+	return w.ch
+}
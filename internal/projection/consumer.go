@@ -0,0 +1,88 @@
+package projection
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+
+	"github.com/yaninyzwitty/cqrs-eccomerce-service/internal/events"
+	"github.com/yaninyzwitty/cqrs-eccomerce-service/internal/messaging"
+)
+
+// Consume receives messages until ctx is cancelled and hands every event to each projector,
+// acking messages all projectors applied and nacking the rest so the broker redelivers them.
+func Consume(ctx context.Context, consumer messaging.Consumer, projectors ...Projector) error {
+	for {
+		msg, err := consumer.Receive(ctx)
+		if err != nil {
+			if ctx.Err() != nil {
+				return nil
+			}
+			return fmt.Errorf("failed to receive message: %w", err)
+		}
+
+		if err := project(ctx, msg, projectors); err != nil {
+			slog.Error("Failed to project event", "error", err, "messageID", msg.ID())
+			consumer.Nack(msg)
+			continue
+		}
+
+		if err := consumer.Ack(msg); err != nil {
+			slog.Error("Failed to ack message", "error", err, "messageID", msg.ID())
+		}
+	}
+}
+
+func project(ctx context.Context, msg messaging.Message, projectors []Projector) error {
+	eventType, err := messageEventType(msg)
+	if err != nil {
+		return err
+	}
+
+	payload, err := messagePayload(msg)
+	if err != nil {
+		return err
+	}
+
+	// events written while handling this one are caused by it
+	properties := msg.Properties()
+	ctx = withEventID(ctx, properties[events.PropertyEventID])
+	ctx = events.WithTrace(ctx, events.Trace{
+		CorrelationID: properties[events.PropertyCorrelationID],
+		CausationID:   properties[events.PropertyEventID],
+	})
+	for _, projector := range projectors {
+		if err := projector.Project(ctx, eventType, payload); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// messageEventType reads the event type from the envelope, falling back to the payload for
+// messages published before events carried one.
+func messageEventType(msg messaging.Message) (string, error) {
+	if eventType := msg.Properties()[events.PropertyEventType]; eventType != "" {
+		return eventType, nil
+	}
+
+	var header eventHeader
+	if err := json.Unmarshal(msg.Payload(), &header); err != nil {
+		return "", fmt.Errorf("error unmarshalling event header: %w", err)
+	}
+	return header.EventType, nil
+}
+
+// messagePayload returns the JSON payload projectors read, decoding protobuf encoded events.
+func messagePayload(msg messaging.Message) ([]byte, error) {
+	if msg.Properties()[events.PropertyContentType] != events.ContentTypeProtobuf {
+		return msg.Payload(), nil
+	}
+
+	payload, err := events.DecodeDomainEvent(msg.Payload())
+	if err != nil {
+		return nil, err
+	}
+	return json.Marshal(payload)
+}
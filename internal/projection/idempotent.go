@@ -0,0 +1,85 @@
+package projection
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+
+	"github.com/gocql/gocql"
+	"github.com/yaninyzwitty/cqrs-eccomerce-service/internal/repository"
+)
+
+type eventIDKey struct{}
+
+func withEventID(ctx context.Context, eventID string) context.Context {
+	return context.WithValue(ctx, eventIDKey{}, eventID)
+}
+
+// EventIDFromContext returns the envelope event id of the message being projected, empty for
+// messages published without an envelope.
+func EventIDFromContext(ctx context.Context) string {
+	eventID, _ := ctx.Value(eventIDKey{}).(string)
+	return eventID
+}
+
+type replayKey struct{}
+
+// withReplay marks the events of a replay, they are applied again even when already applied.
+func withReplay(ctx context.Context) context.Context {
+	return context.WithValue(ctx, replayKey{}, true)
+}
+
+func replaying(ctx context.Context) bool {
+	replay, _ := ctx.Value(replayKey{}).(bool)
+	return replay
+}
+
+// ProjectorFunc lets a plain function handle events.
+type ProjectorFunc func(ctx context.Context, eventType string, payload []byte) error
+
+func (f ProjectorFunc) Project(ctx context.Context, eventType string, payload []byte) error {
+	return f(ctx, eventType, payload)
+}
+
+// idempotentProjector skips events its consumer already applied. An event is recorded only
+// after it was applied, so a crash in between applies it again on redelivery; the window is a
+// single event instead of every duplicate the relay or broker produces.
+type idempotentProjector struct {
+	consumer  string
+	repo      repository.DedupRepository
+	projector Projector
+}
+
+// Idempotent wraps projector so each event id is applied once by consumer. Consumers need
+// distinct names, every projector and subscription tracks its own progress. A replay applies the
+// history regardless and records it, so the live events after it are still deduplicated.
+func Idempotent(consumer string, repo repository.DedupRepository, projector Projector) Projector {
+	return &idempotentProjector{consumer: consumer, repo: repo, projector: projector}
+}
+
+func (p *idempotentProjector) Project(ctx context.Context, eventType string, payload []byte) error {
+	rawID := EventIDFromContext(ctx)
+	if rawID == "" {
+		return p.projector.Project(ctx, eventType, payload)
+	}
+	eventID, err := gocql.ParseUUID(rawID)
+	if err != nil {
+		return fmt.Errorf("invalid event id %q: %w", rawID, err)
+	}
+
+	if !replaying(ctx) {
+		processed, err := p.repo.Processed(ctx, p.consumer, eventID)
+		if err != nil {
+			return err
+		}
+		if processed {
+			slog.Info("Skipping duplicate event", "consumer", p.consumer, "eventID", rawID, "eventType", eventType)
+			return nil
+		}
+	}
+
+	if err := p.projector.Project(ctx, eventType, payload); err != nil {
+		return err
+	}
+	return p.repo.MarkProcessed(ctx, p.consumer, eventID)
+}
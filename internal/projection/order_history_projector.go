@@ -0,0 +1,78 @@
+package projection
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+
+	"github.com/gocql/gocql"
+	"github.com/yaninyzwitty/cqrs-eccomerce-service/internal/events"
+	"github.com/yaninyzwitty/cqrs-eccomerce-service/pb"
+)
+
+// OrderHistoryProjector maintains orders_by_customer_view, the per customer order history.
+type OrderHistoryProjector struct {
+	session *gocql.Session
+}
+
+func NewOrderHistoryProjector(session *gocql.Session) *OrderHistoryProjector {
+	return &OrderHistoryProjector{session: session}
+}
+
+func (p *OrderHistoryProjector) Project(ctx context.Context, eventType string, payload []byte) error {
+	switch eventType {
+	case events.OrderCreated.Name():
+		return p.projectOrderCreated(ctx, payload)
+	case events.OrderStatusChanged.Name():
+		return p.projectOrderStatusChanged(ctx, payload)
+	default:
+		return nil
+	}
+}
+
+func (p *OrderHistoryProjector) projectOrderCreated(ctx context.Context, payload []byte) error {
+	var order pb.Order
+	if err := json.Unmarshal(payload, &order); err != nil {
+		return fmt.Errorf("error unmarshalling order: %w", err)
+	}
+
+	var itemCount int32
+	for _, item := range order.Items {
+		itemCount += item.Quantity
+	}
+
+	err := p.session.Query(
+		`INSERT INTO products_keyspace_v3.orders_by_customer_view
+		(customer_id, order_id, status, total, item_count, created_at, updated_at)
+		VALUES (?, ?, ?, ?, ?, ?, ?)`,
+		order.CustomerId, order.Id, order.Status.String(), order.Total, itemCount,
+		order.CreatedAt.AsTime(), order.UpdatedAt.AsTime(),
+	).WithContext(ctx).Exec()
+	if err != nil {
+		return fmt.Errorf("failed to project order: %w", err)
+	}
+
+	slog.Info("Order projected", "orderID", order.Id, "customerID", order.CustomerId)
+	return nil
+}
+
+func (p *OrderHistoryProjector) projectOrderStatusChanged(ctx context.Context, payload []byte) error {
+	var changed pb.OrderStatusChanged
+	if err := json.Unmarshal(payload, &changed); err != nil {
+		return fmt.Errorf("error unmarshalling order status change: %w", err)
+	}
+
+	err := p.session.Query(
+		`UPDATE products_keyspace_v3.orders_by_customer_view 
+		SET status = ?, updated_at = ? 
+		WHERE customer_id = ? AND order_id = ?`,
+		changed.Status.String(), changed.ChangedAt.AsTime(), changed.CustomerId, changed.OrderId,
+	).WithContext(ctx).Exec()
+	if err != nil {
+		return fmt.Errorf("failed to project order status change: %w", err)
+	}
+
+	slog.Info("Order status change projected", "orderID", changed.OrderId, "status", changed.Status)
+	return nil
+}
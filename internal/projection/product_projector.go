@@ -0,0 +1,203 @@
+package projection
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+
+	"github.com/gocql/gocql"
+	"github.com/yaninyzwitty/cqrs-eccomerce-service/internal/events"
+	"github.com/yaninyzwitty/cqrs-eccomerce-service/pb"
+)
+
+// CategoryListing is the only partition of category_listing_view.
+const CategoryListing = "all"
+
+// ProductProjector keeps the query side read tables in sync with the catalog events.
+type ProductProjector struct {
+	session *gocql.Session
+}
+
+func NewProductProjector(session *gocql.Session) *ProductProjector {
+	return &ProductProjector{session: session}
+}
+
+func (p *ProductProjector) Project(ctx context.Context, eventType string, payload []byte) error {
+	switch eventType {
+	case events.CategoryCreated.Name():
+		return p.projectCategoryCreated(ctx, payload)
+	case events.ProductCreated.Name():
+		return p.projectProductCreated(ctx, payload)
+	case events.ProductUpdated.Name():
+		return p.projectProductUpdated(ctx, payload)
+	case events.ProductDeleted.Name():
+		return p.projectProductDeleted(ctx, payload)
+	case events.CategoryDeleted.Name():
+		return p.projectCategoryDeleted(ctx, payload)
+	case events.InventoryReserved.Name(), events.InventoryReleased.Name(), events.InventoryAdjusted.Name():
+		return p.projectStockChanged(ctx, payload)
+	default:
+		return nil
+	}
+}
+
+func (p *ProductProjector) projectCategoryCreated(ctx context.Context, payload []byte) error {
+	var category pb.Category
+	if err := json.Unmarshal(payload, &category); err != nil {
+		return fmt.Errorf("error unmarshalling category: %w", err)
+	}
+
+	batch := p.session.NewBatch(gocql.LoggedBatch).WithContext(ctx)
+	batch.Query(
+		`INSERT INTO products_keyspace_v3.categories_view
+		(id, name, description, created_at)
+		VALUES (?, ?, ?, ?)`,
+		category.Id, category.Name, category.Description, category.CreatedAt.AsTime(),
+	)
+	batch.Query(
+		`INSERT INTO products_keyspace_v3.category_listing_view
+		(listing, name, id, description, created_at)
+		VALUES (?, ?, ?, ?, ?)`,
+		CategoryListing, category.Name, category.Id, category.Description, category.CreatedAt.AsTime(),
+	)
+	if err := p.session.ExecuteBatch(batch); err != nil {
+		return fmt.Errorf("failed to project category: %w", err)
+	}
+
+	slog.Info("Category projected", "categoryID", category.Id)
+	return nil
+}
+
+func (p *ProductProjector) projectProductCreated(ctx context.Context, payload []byte) error {
+	var product pb.Product
+	if err := json.Unmarshal(payload, &product); err != nil {
+		return fmt.Errorf("error unmarshalling product: %w", err)
+	}
+
+	if err := p.upsertProduct(ctx, &product, true); err != nil {
+		return err
+	}
+
+	slog.Info("Product projected", "productID", product.Id)
+	return nil
+}
+
+func (p *ProductProjector) projectProductUpdated(ctx context.Context, payload []byte) error {
+	var update pb.ProductUpdated
+	if err := json.Unmarshal(payload, &update); err != nil {
+		return fmt.Errorf("error unmarshalling product update: %w", err)
+	}
+	if update.After == nil {
+		return fmt.Errorf("product update is missing the new state")
+	}
+
+	if err := p.upsertProduct(ctx, update.After, false); err != nil {
+		return err
+	}
+
+	slog.Info("Product update projected", "productID", update.After.Id, "fields", update.UpdatedFields)
+	return nil
+}
+
+func (p *ProductProjector) projectProductDeleted(ctx context.Context, payload []byte) error {
+	var deleted pb.ProductDeleted
+	if err := json.Unmarshal(payload, &deleted); err != nil {
+		return fmt.Errorf("error unmarshalling product deletion: %w", err)
+	}
+
+	err := p.session.Query(
+		`DELETE FROM products_keyspace_v3.products_view WHERE category_id = ? AND id = ?`,
+		deleted.CategoryId, deleted.Id,
+	).WithContext(ctx).Exec()
+	if err != nil {
+		return fmt.Errorf("failed to project product deletion: %w", err)
+	}
+
+	slog.Info("Product deletion projected", "productID", deleted.Id)
+	return nil
+}
+
+func (p *ProductProjector) projectCategoryDeleted(ctx context.Context, payload []byte) error {
+	var deleted pb.CategoryDeleted
+	if err := json.Unmarshal(payload, &deleted); err != nil {
+		return fmt.Errorf("error unmarshalling category deletion: %w", err)
+	}
+
+	// the listing is clustered by name, which the tombstone does not carry
+	var name string
+	err := p.session.Query(
+		`SELECT name FROM products_keyspace_v3.categories_view WHERE id = ?`,
+		deleted.Id,
+	).WithContext(ctx).Scan(&name)
+	if err != nil && err != gocql.ErrNotFound {
+		return fmt.Errorf("failed to fetch projected category: %w", err)
+	}
+
+	batch := p.session.NewBatch(gocql.LoggedBatch).WithContext(ctx)
+	batch.Query(
+		`DELETE FROM products_keyspace_v3.categories_view WHERE id = ?`,
+		deleted.Id,
+	)
+	if err == nil {
+		batch.Query(
+			`DELETE FROM products_keyspace_v3.category_listing_view WHERE listing = ? AND name = ? AND id = ?`,
+			CategoryListing, name, deleted.Id,
+		)
+	}
+	for _, productId := range deleted.ProductIds {
+		batch.Query(
+			`DELETE FROM products_keyspace_v3.products_view WHERE category_id = ? AND id = ?`,
+			deleted.Id, productId,
+		)
+	}
+	if err := p.session.ExecuteBatch(batch); err != nil {
+		return fmt.Errorf("failed to project category deletion: %w", err)
+	}
+
+	slog.Info("Category deletion projected", "categoryID", deleted.Id, "products", len(deleted.ProductIds))
+	return nil
+}
+
+// projectStockChanged keeps the stock shown with a product at the units still available to sell.
+func (p *ProductProjector) projectStockChanged(ctx context.Context, payload []byte) error {
+	var changed pb.StockChanged
+	if err := json.Unmarshal(payload, &changed); err != nil {
+		return fmt.Errorf("error unmarshalling stock change: %w", err)
+	}
+	if changed.Stock == nil {
+		return fmt.Errorf("stock change is missing the new stock")
+	}
+
+	err := p.session.Query(
+		`UPDATE products_keyspace_v3.products_view SET stock = ? WHERE category_id = ? AND id = ? IF EXISTS`,
+		changed.Stock.StockCount, changed.Stock.CategoryId, changed.Stock.ProductId,
+	).WithContext(ctx).Exec()
+	if err != nil {
+		return fmt.Errorf("failed to project stock change: %w", err)
+	}
+
+	slog.Info("Stock change projected", "productID", changed.Stock.ProductId, "stock", changed.Stock.StockCount)
+	return nil
+}
+
+// upsertProduct writes a product to the view. Its stock is seeded on creation and only follows
+// the inventory events afterwards, the stock an update carries is not the inventory's.
+func (p *ProductProjector) upsertProduct(ctx context.Context, product *pb.Product, seedStock bool) error {
+	var stock interface{} = gocql.UnsetValue
+	if seedStock {
+		stock = product.Stock
+	}
+
+	err := p.session.Query(
+		`INSERT INTO products_keyspace_v3.products_view
+		(id, category_id, name, description, price, stock, created_at, updated_at)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?)`,
+		product.Id, product.CategoryId, product.Name, product.Description, product.Price, stock,
+		product.CreatedAt.AsTime(), product.UpdatedAt.AsTime(),
+	).WithContext(ctx).Exec()
+	if err != nil {
+		return fmt.Errorf("failed to project product: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,27 @@
+package projection
+
+import (
+	"context"
+	"slices"
+)
+
+// Projector applies published events to a read model, events it does not project are ignored.
+type Projector interface {
+	Project(ctx context.Context, eventType string, payload []byte) error
+}
+
+// eventHeader is the part of every published payload needed to route it.
+type eventHeader struct {
+	EventType string `json:"event_type"`
+}
+
+// Only hands projector the events of eventTypes and ignores the rest. Wrapped around Idempotent it
+// keeps the events the projector ignores from costing a dedup read and write each.
+func Only(projector Projector, eventTypes ...string) Projector {
+	return ProjectorFunc(func(ctx context.Context, eventType string, payload []byte) error {
+		if !slices.Contains(eventTypes, eventType) {
+			return nil
+		}
+		return projector.Project(ctx, eventType, payload)
+	})
+}
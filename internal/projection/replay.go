@@ -0,0 +1,152 @@
+package projection
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/apache/pulsar-client-go/pulsar"
+	"github.com/yaninyzwitty/cqrs-eccomerce-service/internal/messaging"
+)
+
+// replayProgressInterval is how often a replay logs its progress, a replay that receives nothing
+// for this long has caught up.
+const replayProgressInterval = 5 * time.Second
+
+// ReplayStart is where a replay seeks its subscription to: the earliest message, a publish time
+// or a message ID.
+type ReplayStart struct {
+	Time      time.Time
+	MessageID pulsar.MessageID
+}
+
+// ParseReplayStart reads "earliest", an RFC 3339 time or a "ledger:entry:partition" message ID.
+func ParseReplayStart(start string) (ReplayStart, error) {
+	if start == "" || start == "earliest" {
+		return ReplayStart{}, nil
+	}
+	if t, err := time.Parse(time.RFC3339, start); err == nil {
+		return ReplayStart{Time: t}, nil
+	}
+
+	parts := strings.Split(start, ":")
+	if len(parts) != 3 {
+		return ReplayStart{}, fmt.Errorf("replay start %q is not earliest, a time or a message id", start)
+	}
+	var ids [3]int64
+	for i, part := range parts {
+		id, err := strconv.ParseInt(part, 10, 64)
+		if err != nil {
+			return ReplayStart{}, fmt.Errorf("invalid message id %q: %w", start, err)
+		}
+		ids[i] = id
+	}
+	return ReplayStart{MessageID: pulsar.NewMessageID(ids[0], ids[1], -1, int32(ids[2]))}, nil
+}
+
+func (s ReplayStart) String() string {
+	switch {
+	case s.MessageID != nil:
+		return s.MessageID.String()
+	case !s.Time.IsZero():
+		return s.Time.Format(time.RFC3339)
+	default:
+		return "earliest"
+	}
+}
+
+// seek resets the subscription, seeking by message ID is not supported on partitioned topics. The
+// client refuses to seek to the earliest message ID, so earliest seeks to the start of time, which
+// works on partitioned topics too.
+func (s ReplayStart) seek(consumer pulsar.Consumer) error {
+	switch {
+	case s.MessageID != nil:
+		return consumer.Seek(s.MessageID)
+	case !s.Time.IsZero():
+		return consumer.SeekByTime(s.Time)
+	default:
+		return consumer.SeekByTime(time.Unix(0, 0))
+	}
+}
+
+// Replay rebuilds projections from the topic history. It seeks the consumer's subscription to
+// start, projects every event up to the messages that were last when the replay began while
+// logging progress, and then keeps tailing live events like Consume. Replayed events skip the
+// dedup check of Idempotent projectors, which would otherwise drop events already applied. The
+// subscription must be dedicated to the replay, seeking moves every consumer on it. Only Pulsar
+// subscriptions can seek.
+func Replay(ctx context.Context, pulsarConsumer *messaging.PulsarConsumer, start ReplayStart, projectors ...Projector) error {
+	consumer := pulsarConsumer.Pulsar()
+	lastIDs, err := consumer.GetLastMessageIDs()
+	if err != nil {
+		return fmt.Errorf("failed to get last message ids: %w", err)
+	}
+
+	// partitions still behind the messages that were last when the replay started
+	behind := make(map[string]pulsar.MessageID, len(lastIDs))
+	for _, id := range lastIDs {
+		if id.LedgerID() >= 0 && id.EntryID() >= 0 {
+			behind[id.Topic()] = id
+		}
+	}
+
+	if err := start.seek(consumer); err != nil {
+		return fmt.Errorf("failed to seek subscription to %s: %w", start, err)
+	}
+	slog.Info("Replaying events", "from", start, "partitions", len(behind))
+
+	var replayed, failed int
+	var publishTime time.Time
+	began := time.Now()
+	reported := began
+	for len(behind) > 0 {
+		receiveCtx, cancel := context.WithTimeout(ctx, replayProgressInterval)
+		msg, err := consumer.Receive(receiveCtx)
+		cancel()
+		if err != nil {
+			if ctx.Err() != nil {
+				return nil
+			}
+			if errors.Is(err, context.DeadlineExceeded) {
+				// nothing arrived for a while, the start was past the remaining history
+				slog.Info("Replay progress", "replayed", replayed, "failed", failed, "publishTime", publishTime)
+				break
+			}
+			return fmt.Errorf("failed to receive message: %w", err)
+		}
+
+		if err := project(withReplay(ctx), messaging.NewPulsarMessage(msg), projectors); err != nil {
+			slog.Error("Failed to project event", "error", err, "messageID", msg.ID())
+			consumer.Nack(msg)
+			failed++
+		} else if err := consumer.Ack(msg); err != nil {
+			slog.Error("Failed to ack message", "error", err, "messageID", msg.ID())
+		}
+		replayed++
+		publishTime = msg.PublishTime()
+
+		if last, ok := behind[msg.Topic()]; ok && !before(msg.ID(), last) {
+			delete(behind, msg.Topic())
+		}
+		if time.Since(reported) >= replayProgressInterval {
+			reported = time.Now()
+			slog.Info("Replay progress", "replayed", replayed, "failed", failed,
+				"publishTime", publishTime, "partitionsBehind", len(behind))
+		}
+	}
+
+	slog.Info("Replay caught up, tailing live events", "replayed", replayed, "failed", failed,
+		"duration", time.Since(began))
+	return Consume(ctx, pulsarConsumer, projectors...)
+}
+
+func before(id, other pulsar.MessageID) bool {
+	if id.LedgerID() != other.LedgerID() {
+		return id.LedgerID() < other.LedgerID()
+	}
+	return id.EntryID() < other.EntryID()
+}
@@ -0,0 +1,71 @@
+package queue
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+
+	"github.com/segmentio/kafka-go"
+)
+
+// KafkaMethods defines the interface for Kafka-related operations
+type KafkaMethods interface {
+	CreateKafkaWriter(ctx context.Context, producerTopic string) (*kafka.Writer, error)
+	CreateKafkaReader(ctx context.Context, consumerTopic string) (*kafka.Reader, error)
+}
+
+// KafkaConfig holds the configuration for the Kafka connection
+type KafkaConfig struct {
+	Brokers []string
+	// GroupID is the consumer group readers join, defaultSubscriptionName when empty.
+	GroupID string
+}
+
+func NewKafka(cfg *KafkaConfig) KafkaMethods {
+	return &KafkaConfig{
+		Brokers: cfg.Brokers,
+		GroupID: cfg.GroupID,
+	}
+}
+
+// CreateKafkaWriter creates a writer for a topic. Messages are partitioned by key, so the events
+// of one aggregate stay in order.
+func (c *KafkaConfig) CreateKafkaWriter(ctx context.Context, producerTopic string) (*kafka.Writer, error) {
+	if len(c.Brokers) == 0 {
+		return nil, fmt.Errorf("no kafka brokers configured")
+	}
+
+	writer := &kafka.Writer{
+		Addr:         kafka.TCP(c.Brokers...),
+		Topic:        producerTopic,
+		Balancer:     &kafka.Hash{},
+		RequiredAcks: kafka.RequireAll,
+	}
+
+	slog.Info("Kafka writer created successfully", "topic", producerTopic)
+
+	return writer, nil
+}
+
+// CreateKafkaReader joins the consumer group on a topic, a new group starts at the earliest offset.
+func (c *KafkaConfig) CreateKafkaReader(ctx context.Context, consumerTopic string) (*kafka.Reader, error) {
+	if len(c.Brokers) == 0 {
+		return nil, fmt.Errorf("no kafka brokers configured")
+	}
+
+	groupID := c.GroupID
+	if groupID == "" {
+		groupID = defaultSubscriptionName
+	}
+
+	reader := kafka.NewReader(kafka.ReaderConfig{
+		Brokers:     c.Brokers,
+		GroupID:     groupID,
+		Topic:       consumerTopic,
+		StartOffset: kafka.FirstOffset,
+	})
+
+	slog.Info("Kafka reader created successfully", "topic", consumerTopic, "group", groupID)
+
+	return reader, nil
+}
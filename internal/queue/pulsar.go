@@ -8,34 +8,60 @@ import (
 	"github.com/apache/pulsar-client-go/pulsar"
 )
 
+const defaultSubscriptionName = "my-subscription"
+
 // PulsarMethods defines the interface for Pulsar-related operations
 type PulsarMethods interface {
 	CreatePulsarConnection(ctx context.Context) (pulsar.Client, error)
-	CreatePulsarProducer(ctx context.Context, client pulsar.Client) (pulsar.Producer, error)
+	CreatePulsarProducer(ctx context.Context, client pulsar.Client, producerTopic string) (pulsar.Producer, error)
 	CreatePulsarConsumer(ctx context.Context, client pulsar.Client, consumerTopic string) (pulsar.Consumer, error)
 }
 
 // PulsarConfig holds the configuration for the Pulsar connection
 type PulsarConfig struct {
-	URI       string
-	Token     string
-	TopicName string
+	URI string
+	// Token is sent in token auth mode.
+	Token string
+	Auth  PulsarAuth
+	// SubscriptionName is used by consumers, defaultSubscriptionName when empty.
+	SubscriptionName string
+	// ProducerSchema is registered with the topic by the producer, nil publishes plain bytes.
+	ProducerSchema pulsar.Schema
+	Producer       PulsarProducerOptions
+	Consumer       PulsarConsumerOptions
 }
 
 // NewPulsar initializes and returns a PulsarConfig instance that implements PulsarMethods
 func NewPulsar(cfg *PulsarConfig) PulsarMethods {
 	return &PulsarConfig{
-		URI:       cfg.URI,
-		Token:     cfg.Token,
-		TopicName: cfg.TopicName,
+		URI:              cfg.URI,
+		Token:            cfg.Token,
+		Auth:             cfg.Auth,
+		SubscriptionName: cfg.SubscriptionName,
+		ProducerSchema:   cfg.ProducerSchema,
+		Producer:         cfg.Producer,
+		Consumer:         cfg.Consumer,
 	}
 }
 
-// CreatePulsarConnection establishes a connection to the Pulsar server
+// CreatePulsarConnection establishes a connection to the Pulsar server, it fails on invalid
+// producer or consumer options so they are caught at startup.
 func (c *PulsarConfig) CreatePulsarConnection(ctx context.Context) (pulsar.Client, error) {
+	if err := c.Auth.validate(); err != nil {
+		return nil, fmt.Errorf("invalid Pulsar authentication: %w", err)
+	}
+	if err := c.Producer.validate(); err != nil {
+		return nil, fmt.Errorf("invalid Pulsar producer options: %w", err)
+	}
+	if err := c.Consumer.validate(); err != nil {
+		return nil, fmt.Errorf("invalid Pulsar consumer options: %w", err)
+	}
+
 	clientOptions := pulsar.ClientOptions{
-		URL:            c.URI,
-		Authentication: pulsar.NewAuthenticationToken(c.Token),
+		URL: c.URI,
+	}
+	if err := c.Auth.apply(&clientOptions, c.Token); err != nil {
+		return nil, err
 	}
 
 	client, err := pulsar.NewClient(clientOptions)
@@ -43,41 +69,52 @@ func (c *PulsarConfig) CreatePulsarConnection(ctx context.Context) (pulsar.Clien
 		return nil, fmt.Errorf("failed to instantiate Pulsar client: %w", err)
 	}
 
-	slog.Info("Pulsar connection created successfully")
+	slog.Info("Pulsar connection created successfully", "auth", c.Auth.Mode)
 
 	return client, nil
 }
 
 // CreatePulsarProducer creates a new producer for a specified topic
-func (c *PulsarConfig) CreatePulsarProducer(ctx context.Context, client pulsar.Client) (pulsar.Producer, error) {
-	slog.Info("name", "value", c.TopicName)
+func (c *PulsarConfig) CreatePulsarProducer(ctx context.Context, client pulsar.Client, producerTopic string) (pulsar.Producer, error) {
 	producerOptions := pulsar.ProducerOptions{
-		Topic: c.TopicName,
+		Topic:  producerTopic,
+		Schema: c.ProducerSchema,
 	}
+	c.Producer.apply(&producerOptions)
 
 	producer, err := client.CreateProducer(producerOptions)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create Pulsar producer: %w", err)
 	}
 
-	slog.Info("Pulsar producer created successfully", "topic", c.TopicName)
+	slog.Info("Pulsar producer created successfully", "topic", producerTopic)
 
 	return producer, nil
 }
 
 func (c *PulsarConfig) CreatePulsarConsumer(ctx context.Context, client pulsar.Client, consumerTopic string) (pulsar.Consumer, error) {
+	subscriptionName := c.SubscriptionName
+	if subscriptionName == "" {
+		subscriptionName = defaultSubscriptionName
+	}
+
 	consumerOptions := pulsar.ConsumerOptions{
 		Topic:                       consumerTopic,
-		SubscriptionName:            "my-subscription",
+		SubscriptionName:            subscriptionName,
 		SubscriptionInitialPosition: pulsar.SubscriptionPositionEarliest,
 	}
+	c.Consumer.apply(&consumerOptions)
 
 	consumer, err := client.Subscribe(consumerOptions)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create Pulsar consumer: %w", err)
 	}
 
-	slog.Info("Pulsar consumer created successfully", "topic", c.TopicName)
+	slog.Info("Pulsar consumer created successfully", "topic", consumerTopic, "subscription", subscriptionName,
+		"type", consumerOptions.Type)
 
+	if c.Consumer.AckTimeout > 0 {
+		return newAckTimeoutConsumer(consumer, c.Consumer.AckTimeout), nil
+	}
 	return consumer, nil
 }
@@ -0,0 +1,110 @@
+package queue
+
+import (
+	"context"
+	"log/slog"
+	"sync"
+	"time"
+
+	"github.com/apache/pulsar-client-go/pulsar"
+)
+
+// ackTimeoutConsumer nacks received messages that were not acked within the timeout, so a stuck
+// handler does not hold them forever. The Go client has no ack timeout of its own.
+type ackTimeoutConsumer struct {
+	pulsar.Consumer
+	timeout time.Duration
+
+	mu      sync.Mutex
+	pending map[string]pendingAck
+	stop    chan struct{}
+	once    sync.Once
+}
+
+type pendingAck struct {
+	msg      pulsar.Message
+	deadline time.Time
+}
+
+func newAckTimeoutConsumer(consumer pulsar.Consumer, timeout time.Duration) *ackTimeoutConsumer {
+	c := &ackTimeoutConsumer{
+		Consumer: consumer,
+		timeout:  timeout,
+		pending:  make(map[string]pendingAck),
+		stop:     make(chan struct{}),
+	}
+	go c.expire()
+	return c
+}
+
+func (c *ackTimeoutConsumer) Receive(ctx context.Context) (pulsar.Message, error) {
+	msg, err := c.Consumer.Receive(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	c.mu.Lock()
+	c.pending[msg.ID().String()] = pendingAck{msg: msg, deadline: time.Now().Add(c.timeout)}
+	c.mu.Unlock()
+	return msg, nil
+}
+
+func (c *ackTimeoutConsumer) Ack(msg pulsar.Message) error {
+	c.forget(msg.ID())
+	return c.Consumer.Ack(msg)
+}
+
+func (c *ackTimeoutConsumer) AckID(id pulsar.MessageID) error {
+	c.forget(id)
+	return c.Consumer.AckID(id)
+}
+
+func (c *ackTimeoutConsumer) Nack(msg pulsar.Message) {
+	c.forget(msg.ID())
+	c.Consumer.Nack(msg)
+}
+
+func (c *ackTimeoutConsumer) NackID(id pulsar.MessageID) {
+	c.forget(id)
+	c.Consumer.NackID(id)
+}
+
+func (c *ackTimeoutConsumer) Close() {
+	c.once.Do(func() { close(c.stop) })
+	c.Consumer.Close()
+}
+
+func (c *ackTimeoutConsumer) forget(id pulsar.MessageID) {
+	c.mu.Lock()
+	delete(c.pending, id.String())
+	c.mu.Unlock()
+}
+
+func (c *ackTimeoutConsumer) expire() {
+	ticker := time.NewTicker(max(c.timeout/4, 100*time.Millisecond))
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+		case <-c.stop:
+			return
+		}
+
+		now := time.Now()
+		var expired []pulsar.Message
+		c.mu.Lock()
+		for key, pending := range c.pending {
+			if now.After(pending.deadline) {
+				expired = append(expired, pending.msg)
+				delete(c.pending, key)
+			}
+		}
+		c.mu.Unlock()
+
+		for _, msg := range expired {
+			slog.Warn("Message was not acked in time, redelivering", "messageID", msg.ID(), "timeout", c.timeout)
+			c.Consumer.Nack(msg)
+		}
+	}
+}
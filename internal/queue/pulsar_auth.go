@@ -0,0 +1,87 @@
+package queue
+
+import (
+	"fmt"
+
+	"github.com/apache/pulsar-client-go/pulsar"
+	"github.com/apache/pulsar-client-go/pulsar/auth"
+)
+
+// Pulsar authentication modes
+const (
+	PulsarAuthToken  = "token"
+	PulsarAuthTLS    = "tls"
+	PulsarAuthOAuth2 = "oauth2"
+	PulsarAuthNone   = "none"
+)
+
+// PulsarAuth selects how clients authenticate with the cluster, read from queue.pulsar.auth in
+// config.yaml. Secrets stay in files or the environment, the config only points at them.
+type PulsarAuth struct {
+	// Mode is token, tls, oauth2 or none, token when empty. Token mode sends PulsarConfig.Token,
+	// none suits a local standalone Pulsar.
+	Mode string `yaml:"mode"`
+	// TrustCertsFile is a CA bundle trusted for the broker certificate, the system roots are
+	// used when empty.
+	TrustCertsFile string `yaml:"trust_certs_file"`
+	// ValidateHostname checks the broker certificate against the host in the service URL.
+	ValidateHostname bool `yaml:"validate_hostname"`
+	// CertFile and KeyFile are the client certificate and key of tls mode.
+	CertFile string     `yaml:"cert_file"`
+	KeyFile  string     `yaml:"key_file"`
+	OAuth2   OAuth2Auth `yaml:"oauth2"`
+}
+
+// OAuth2Auth configures the client credentials flow of oauth2 mode.
+type OAuth2Auth struct {
+	IssuerURL string `yaml:"issuer_url"`
+	Audience  string `yaml:"audience"`
+	Scope     string `yaml:"scope"`
+	// CredentialsFile is the JSON key file holding client_id and client_secret, a path or a
+	// file:// or data: URL.
+	CredentialsFile string `yaml:"credentials_file"`
+}
+
+func (a PulsarAuth) validate() error {
+	switch a.Mode {
+	case "", PulsarAuthToken, PulsarAuthNone:
+	case PulsarAuthTLS:
+		if a.CertFile == "" || a.KeyFile == "" {
+			return fmt.Errorf("tls authentication needs a cert file and a key file")
+		}
+	case PulsarAuthOAuth2:
+		if a.OAuth2.IssuerURL == "" || a.OAuth2.Audience == "" || a.OAuth2.CredentialsFile == "" {
+			return fmt.Errorf("oauth2 authentication needs an issuer url, an audience and a credentials file")
+		}
+	default:
+		return fmt.Errorf("unknown authentication mode %q, expected token, tls, oauth2 or none", a.Mode)
+	}
+	return nil
+}
+
+// apply sets up authentication and broker trust on the client options.
+func (a PulsarAuth) apply(options *pulsar.ClientOptions, token string) error {
+	options.TLSTrustCertsFilePath = a.TrustCertsFile
+	options.TLSValidateHostname = a.ValidateHostname
+
+	switch a.Mode {
+	case "", PulsarAuthToken:
+		options.Authentication = pulsar.NewAuthenticationToken(token)
+	case PulsarAuthTLS:
+		options.Authentication = pulsar.NewAuthenticationTLS(a.CertFile, a.KeyFile)
+	case PulsarAuthOAuth2:
+		// the client library variant drops the error, this one reports a bad key file or issuer
+		provider, err := auth.NewAuthenticationOAuth2WithParams(map[string]string{
+			auth.ConfigParamType:      auth.ConfigParamTypeClientCredentials,
+			auth.ConfigParamIssuerURL: a.OAuth2.IssuerURL,
+			auth.ConfigParamAudience:  a.OAuth2.Audience,
+			auth.ConfigParamScope:     a.OAuth2.Scope,
+			auth.ConfigParamKeyFile:   a.OAuth2.CredentialsFile,
+		})
+		if err != nil {
+			return fmt.Errorf("failed to set up oauth2 authentication: %w", err)
+		}
+		options.Authentication = provider
+	}
+	return nil
+}
@@ -0,0 +1,107 @@
+package queue
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/apache/pulsar-client-go/pulsar"
+)
+
+// PulsarProducerOptions tunes the producers, zero values keep the client defaults. They are read
+// from queue.pulsar.producer in config.yaml.
+type PulsarProducerOptions struct {
+	// Name prefixes the producer names, the broker assigns one when empty. Names are unique per
+	// topic, so the command server appends its host name, which a replica keeps across restarts.
+	Name        string        `yaml:"name"`
+	SendTimeout time.Duration `yaml:"send_timeout"`
+	// DisableBatching sends every message on its own, otherwise messages are batched by key so
+	// Key_Shared subscriptions still receive each key in order.
+	DisableBatching         bool          `yaml:"disable_batching"`
+	BatchingMaxPublishDelay time.Duration `yaml:"batching_max_publish_delay"`
+	BatchingMaxMessages     uint          `yaml:"batching_max_messages"`
+	// Compression is none, lz4, zlib or zstd.
+	Compression string `yaml:"compression"`
+	// Deduplication sets the sequence id of every message from its outbox row, so the broker
+	// drops rows the relay sends again after a restart. It requires a Name and must be enabled
+	// on the namespace or topic by an admin.
+	Deduplication bool `yaml:"deduplication"`
+}
+
+// PulsarConsumerOptions tunes the consumers, zero values keep the client defaults. They are read
+// from queue.pulsar.consumer in config.yaml.
+type PulsarConsumerOptions struct {
+	// SubscriptionType is exclusive, shared, failover or key_shared, exclusive when empty.
+	SubscriptionType string `yaml:"subscription_type"`
+	// AckTimeout redelivers messages that were received but not acked in time.
+	AckTimeout          time.Duration `yaml:"ack_timeout"`
+	NackRedeliveryDelay time.Duration `yaml:"nack_redelivery_delay"`
+	// DeadLetter moves messages to a dead letter topic once they were delivered MaxDeliveries
+	// times, it needs a shared or key_shared subscription.
+	DeadLetter PulsarDeadLetterPolicy `yaml:"dead_letter"`
+}
+
+type PulsarDeadLetterPolicy struct {
+	MaxDeliveries uint32 `yaml:"max_deliveries"`
+	// Topic defaults to <topic>-<subscription>-DLQ.
+	Topic string `yaml:"topic"`
+}
+
+var compressionTypes = map[string]pulsar.CompressionType{
+	"":     pulsar.NoCompression,
+	"none": pulsar.NoCompression,
+	"lz4":  pulsar.LZ4,
+	"zlib": pulsar.ZLib,
+	"zstd": pulsar.ZSTD,
+}
+
+var subscriptionTypes = map[string]pulsar.SubscriptionType{
+	"":           pulsar.Exclusive,
+	"exclusive":  pulsar.Exclusive,
+	"shared":     pulsar.Shared,
+	"failover":   pulsar.Failover,
+	"key_shared": pulsar.KeyShared,
+}
+
+func (o PulsarProducerOptions) validate() error {
+	if _, ok := compressionTypes[o.Compression]; !ok {
+		return fmt.Errorf("unknown compression %q, expected none, lz4, zlib or zstd", o.Compression)
+	}
+	if o.Deduplication && o.Name == "" {
+		return fmt.Errorf("producer deduplication needs a producer name")
+	}
+	return nil
+}
+
+func (o PulsarConsumerOptions) validate() error {
+	subscriptionType, ok := subscriptionTypes[o.SubscriptionType]
+	if !ok {
+		return fmt.Errorf("unknown subscription type %q, expected exclusive, shared, failover or key_shared", o.SubscriptionType)
+	}
+	if o.DeadLetter.MaxDeliveries > 0 && subscriptionType != pulsar.Shared && subscriptionType != pulsar.KeyShared {
+		return fmt.Errorf("dead letter policy needs a shared or key_shared subscription")
+	}
+	return nil
+}
+
+func (o PulsarProducerOptions) apply(options *pulsar.ProducerOptions) {
+	options.Name = o.Name
+	options.SendTimeout = o.SendTimeout
+	options.DisableBatching = o.DisableBatching
+	options.BatchingMaxPublishDelay = o.BatchingMaxPublishDelay
+	options.BatchingMaxMessages = o.BatchingMaxMessages
+	options.CompressionType = compressionTypes[o.Compression]
+	if !o.DisableBatching {
+		options.BatcherBuilderType = pulsar.KeyBasedBatchBuilder
+	}
+}
+
+func (o PulsarConsumerOptions) apply(options *pulsar.ConsumerOptions) {
+	options.Type = subscriptionTypes[o.SubscriptionType]
+	options.NackRedeliveryDelay = o.NackRedeliveryDelay
+	if o.DeadLetter.MaxDeliveries > 0 {
+		options.DLQ = &pulsar.DLQPolicy{
+			MaxDeliveries:   o.DeadLetter.MaxDeliveries,
+			DeadLetterTopic: o.DeadLetter.Topic,
+		}
+	}
+}
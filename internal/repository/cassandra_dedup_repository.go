@@ -0,0 +1,51 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/gocql/gocql"
+)
+
+// DefaultDedupTTL applies when the config does not set how long processed events are remembered.
+const DefaultDedupTTL = 7 * 24 * time.Hour
+
+// DedupRepository remembers which events each consumer has already applied.
+type DedupRepository interface {
+	Processed(ctx context.Context, consumer string, eventID gocql.UUID) (bool, error)
+	MarkProcessed(ctx context.Context, consumer string, eventID gocql.UUID) error
+}
+
+type CassandraDedupRepository struct {
+	session *gocql.Session
+	ttl     time.Duration
+}
+
+func NewCassandraDedupRepository(session *gocql.Session, ttl time.Duration) *CassandraDedupRepository {
+	if ttl < time.Second {
+		ttl = DefaultDedupTTL
+	}
+	return &CassandraDedupRepository{session: session, ttl: ttl}
+}
+
+func (r *CassandraDedupRepository) Processed(ctx context.Context, consumer string, eventID gocql.UUID) (bool, error) {
+	query := `SELECT event_id FROM products_keyspace_v3.processed_events WHERE consumer = ? AND event_id = ?`
+	var id gocql.UUID
+	err := r.session.Query(query, consumer, eventID).WithContext(ctx).Scan(&id)
+	if err == gocql.ErrNotFound {
+		return false, nil
+	}
+	if err != nil {
+		return false, fmt.Errorf("failed to look up processed event: %w", err)
+	}
+	return true, nil
+}
+
+func (r *CassandraDedupRepository) MarkProcessed(ctx context.Context, consumer string, eventID gocql.UUID) error {
+	query := `INSERT INTO products_keyspace_v3.processed_events (consumer, event_id, processed_at) VALUES (?, ?, ?) USING TTL ?`
+	if err := r.session.Query(query, consumer, eventID, time.Now(), int(r.ttl.Seconds())).WithContext(ctx).Exec(); err != nil {
+		return fmt.Errorf("failed to record processed event: %w", err)
+	}
+	return nil
+}
@@ -3,22 +3,70 @@ package repository
 import (
 	"context"
 	"fmt"
+	"sort"
+	"time"
 
 	"log/slog"
 
 	"github.com/gocql/gocql"
 )
 
+// OutboxBucketShard is the only partition of the outbox_buckets index.
+const OutboxBucketShard = 0
+
+const outboxBucketLayout = "2006-01-02"
+
+// OutboxBucket returns the outbox partition a message written at t belongs to.
+func OutboxBucket(t time.Time) string {
+	return t.Format(outboxBucketLayout)
+}
+
+// OutboxBucketEnd returns the time after which no writer should add rows to bucket.
+func OutboxBucketEnd(bucket string) (time.Time, error) {
+	start, err := time.ParseInLocation(outboxBucketLayout, bucket, time.Local)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("invalid outbox bucket %q: %w", bucket, err)
+	}
+	return start.AddDate(0, 0, 1), nil
+}
+
 type OutboxMessage struct {
 	Id        gocql.UUID
 	Bucket    string
 	EventType string
 	Payload   string
+	// CorrelationID and CausationID trace the request that wrote the message.
+	CorrelationID string
+	CausationID   string
+	// Attempts counts the failed relay attempts, the row is not retried before NextAttemptAt.
+	Attempts      int
+	NextAttemptAt time.Time
+	LastError     string
+	// DeadLetteredAt is only set on rows of the dead letter table.
+	DeadLetteredAt time.Time
+	// DeliverAt is set on scheduled events, consumers must not see them before.
+	DeliverAt time.Time
 }
 
 type OutboxRepository interface {
+	FetchBuckets(ctx context.Context) ([]string, error)
+	DeleteBucket(ctx context.Context, bucket string) error
+	FetchMessages(ctx context.Context, bucket string) ([]OutboxMessage, error)
+	DeleteMessages(ctx context.Context, messages []OutboxMessage) error
+	RecordFailure(ctx context.Context, message OutboxMessage) error
+	DeadLetter(ctx context.Context, message OutboxMessage) error
+}
+
+// OutboxAdminRepository inspects and repairs the outbox and its dead letters by hand.
+type OutboxAdminRepository interface {
+	FetchBuckets(ctx context.Context) ([]string, error)
 	FetchMessages(ctx context.Context, bucket string) ([]OutboxMessage, error)
-	DeleteMessage(ctx context.Context, message OutboxMessage) error
+	DeleteMessages(ctx context.Context, messages []OutboxMessage) error
+	ResetMessages(ctx context.Context, messages []OutboxMessage) error
+	FetchDeadLetterBuckets(ctx context.Context) ([]string, error)
+	FetchDeadLetters(ctx context.Context, bucket string) ([]OutboxMessage, error)
+	RequeueDeadLetters(ctx context.Context, messages []OutboxMessage) error
+	DeleteDeadLetters(ctx context.Context, messages []OutboxMessage) error
 }
 
 type CassandraOutboxRepository struct {
@@ -29,15 +77,45 @@ func NewCassandraOutboxRepository(session *gocql.Session) *CassandraOutboxReposi
 	return &CassandraOutboxRepository{session: session}
 }
 
+// FetchBuckets returns the indexed outbox buckets, oldest first.
+func (r *CassandraOutboxRepository) FetchBuckets(ctx context.Context) ([]string, error) {
+	query := `SELECT bucket FROM products_keyspace_v3.outbox_buckets WHERE shard = ?`
+	iter := r.session.Query(query, OutboxBucketShard).WithContext(ctx).Iter()
+	defer iter.Close()
+
+	var buckets []string
+	var bucket string
+	for iter.Scan(&bucket) {
+		buckets = append(buckets, bucket)
+	}
+
+	if err := iter.Close(); err != nil {
+		return nil, err
+	}
+	return buckets, nil
+}
+
+func (r *CassandraOutboxRepository) DeleteBucket(ctx context.Context, bucket string) error {
+	query := `DELETE FROM products_keyspace_v3.outbox_buckets WHERE shard = ? AND bucket = ?`
+	err := r.session.Query(query, OutboxBucketShard, bucket).WithContext(ctx).Exec()
+	if err != nil {
+		return fmt.Errorf("failed to delete bucket: %w", err)
+	}
+
+	slog.Info("Outbox bucket drained", "bucket", bucket)
+	return nil
+}
+
 func (r *CassandraOutboxRepository) FetchMessages(ctx context.Context, bucket string) ([]OutboxMessage, error) {
-	query := `SELECT id, bucket, payload, event_type FROM products_keyspace_v3.outbox WHERE bucket = ? ORDER BY id ASC;`
+	query := `SELECT id, bucket, payload, event_type, correlation_id, causation_id, attempts, next_attempt_at, last_error, deliver_at
+		FROM products_keyspace_v3.outbox WHERE bucket = ? ORDER BY id ASC;`
 	iter := r.session.Query(query, bucket).WithContext(ctx).Iter()
 	defer iter.Close()
 
 	var messages []OutboxMessage
 	for {
 		var msg OutboxMessage
-		if !iter.Scan(&msg.Id, &msg.Bucket, &msg.Payload, &msg.EventType) {
+		if !iter.Scan(&msg.Id, &msg.Bucket, &msg.Payload, &msg.EventType, &msg.CorrelationID, &msg.CausationID, &msg.Attempts, &msg.NextAttemptAt, &msg.LastError, &msg.DeliverAt) {
 			break
 		}
 		messages = append(messages, msg)
@@ -49,13 +127,171 @@ func (r *CassandraOutboxRepository) FetchMessages(ctx context.Context, bucket st
 	return messages, nil
 }
 
-func (r *CassandraOutboxRepository) DeleteMessage(ctx context.Context, message OutboxMessage) error {
-	query := `DELETE FROM products_keyspace_v3.outbox WHERE bucket = ? AND id = ?`
-	err := r.session.Query(query, message.Bucket, message.Id).WithContext(ctx).Exec()
+// DeleteMessages removes published messages, with one unlogged batch per bucket partition.
+func (r *CassandraOutboxRepository) DeleteMessages(ctx context.Context, messages []OutboxMessage) error {
+	batches := make(map[string]*gocql.Batch)
+	for _, message := range messages {
+		batch, ok := batches[message.Bucket]
+		if !ok {
+			batch = r.session.NewBatch(gocql.UnloggedBatch).WithContext(ctx)
+			batches[message.Bucket] = batch
+		}
+		batch.Query(`DELETE FROM products_keyspace_v3.outbox WHERE bucket = ? AND id = ?`, message.Bucket, message.Id)
+	}
+
+	for bucket, batch := range batches {
+		if err := r.session.ExecuteBatch(batch); err != nil {
+			return fmt.Errorf("failed to delete messages from bucket %s: %w", bucket, err)
+		}
+	}
+
+	slog.Info("Messages deleted", "count", len(messages))
+	return nil
+}
+
+// RecordFailure stores the attempt counter, retry time and error of a message that failed to relay.
+func (r *CassandraOutboxRepository) RecordFailure(ctx context.Context, message OutboxMessage) error {
+	query := `UPDATE products_keyspace_v3.outbox
+		SET attempts = ?, next_attempt_at = ?, last_error = ?
+		WHERE bucket = ? AND id = ?`
+	err := r.session.Query(query,
+		message.Attempts, message.NextAttemptAt, message.LastError, message.Bucket, message.Id,
+	).WithContext(ctx).Exec()
 	if err != nil {
-		return fmt.Errorf("failed to delete message: %w", err)
+		return fmt.Errorf("failed to record message failure: %w", err)
+	}
+	return nil
+}
+
+// DeadLetter moves a message out of the outbox so the relay stops retrying it.
+func (r *CassandraOutboxRepository) DeadLetter(ctx context.Context, message OutboxMessage) error {
+	batch := r.session.NewBatch(gocql.LoggedBatch).WithContext(ctx)
+	batch.Query(`INSERT INTO products_keyspace_v3.outbox_dead_letter
+		(bucket, id, payload, event_type, correlation_id, causation_id, attempts, last_error, dead_lettered_at)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?)`,
+		message.Bucket, message.Id, message.Payload, message.EventType, message.CorrelationID, message.CausationID,
+		message.Attempts, message.LastError, time.Now(),
+	)
+	addDeliverAt(batch, "outbox_dead_letter", message)
+	batch.Query(`DELETE FROM products_keyspace_v3.outbox WHERE bucket = ? AND id = ?`, message.Bucket, message.Id)
+	if err := r.session.ExecuteBatch(batch); err != nil {
+		return fmt.Errorf("failed to dead letter message: %w", err)
+	}
+
+	slog.Warn("Message dead lettered", "messageID", message.Id, "eventType", message.EventType,
+		"attempts", message.Attempts, "error", message.LastError)
+	return nil
+}
+
+// ResetMessages clears the retry state of pending messages so the relay publishes them on its next pass.
+func (r *CassandraOutboxRepository) ResetMessages(ctx context.Context, messages []OutboxMessage) error {
+	for _, message := range messages {
+		query := `UPDATE products_keyspace_v3.outbox
+			SET attempts = 0, next_attempt_at = null, last_error = null
+			WHERE bucket = ? AND id = ? IF EXISTS`
+		_, err := r.session.Query(query, message.Bucket, message.Id).WithContext(ctx).MapScanCAS(map[string]interface{}{})
+		if err != nil {
+			return fmt.Errorf("failed to reset message %v: %w", message.Id, err)
+		}
+	}
+	return nil
+}
+
+// FetchDeadLetterBuckets returns the buckets holding dead letters, oldest first.
+func (r *CassandraOutboxRepository) FetchDeadLetterBuckets(ctx context.Context) ([]string, error) {
+	query := `SELECT DISTINCT bucket FROM products_keyspace_v3.outbox_dead_letter`
+	iter := r.session.Query(query).WithContext(ctx).Iter()
+	defer iter.Close()
+
+	var buckets []string
+	var bucket string
+	for iter.Scan(&bucket) {
+		buckets = append(buckets, bucket)
+	}
+
+	if err := iter.Close(); err != nil {
+		return nil, err
+	}
+	sort.Strings(buckets)
+	return buckets, nil
+}
+
+func (r *CassandraOutboxRepository) FetchDeadLetters(ctx context.Context, bucket string) ([]OutboxMessage, error) {
+	query := `SELECT id, bucket, payload, event_type, correlation_id, causation_id, attempts, last_error, dead_lettered_at, deliver_at
+		FROM products_keyspace_v3.outbox_dead_letter WHERE bucket = ? ORDER BY id ASC`
+	iter := r.session.Query(query, bucket).WithContext(ctx).Iter()
+	defer iter.Close()
+
+	var messages []OutboxMessage
+	for {
+		var msg OutboxMessage
+		if !iter.Scan(&msg.Id, &msg.Bucket, &msg.Payload, &msg.EventType, &msg.CorrelationID, &msg.CausationID,
+			&msg.Attempts, &msg.LastError, &msg.DeadLetteredAt, &msg.DeliverAt) {
+			break
+		}
+		messages = append(messages, msg)
+	}
+
+	if err := iter.Close(); err != nil {
+		return nil, err
+	}
+	return messages, nil
+}
+
+// RequeueDeadLetters moves dead letters back to the outbox with a fresh attempt budget. They keep
+// their id, so consumers still see the event id the row was written with.
+func (r *CassandraOutboxRepository) RequeueDeadLetters(ctx context.Context, messages []OutboxMessage) error {
+	for _, message := range messages {
+		batch := r.session.NewBatch(gocql.LoggedBatch).WithContext(ctx)
+		batch.Query(`INSERT INTO products_keyspace_v3.outbox
+			(id, bucket, payload, event_type, correlation_id, causation_id)
+			VALUES (?, ?, ?, ?, ?, ?)`,
+			message.Id, message.Bucket, message.Payload, message.EventType, message.CorrelationID, message.CausationID,
+		)
+		addDeliverAt(batch, "outbox", message)
+		batch.Query(`INSERT INTO products_keyspace_v3.outbox_buckets (shard, bucket) VALUES (?, ?)`,
+			OutboxBucketShard, message.Bucket,
+		)
+		batch.Query(`DELETE FROM products_keyspace_v3.outbox_dead_letter WHERE bucket = ? AND id = ?`,
+			message.Bucket, message.Id,
+		)
+		if err := r.session.ExecuteBatch(batch); err != nil {
+			return fmt.Errorf("failed to requeue dead letter %v: %w", message.Id, err)
+		}
+	}
+
+	slog.Info("Dead letters requeued", "count", len(messages))
+	return nil
+}
+
+func (r *CassandraOutboxRepository) DeleteDeadLetters(ctx context.Context, messages []OutboxMessage) error {
+	batches := make(map[string]*gocql.Batch)
+	for _, message := range messages {
+		batch, ok := batches[message.Bucket]
+		if !ok {
+			batch = r.session.NewBatch(gocql.UnloggedBatch).WithContext(ctx)
+			batches[message.Bucket] = batch
+		}
+		batch.Query(`DELETE FROM products_keyspace_v3.outbox_dead_letter WHERE bucket = ? AND id = ?`, message.Bucket, message.Id)
 	}
 
-	slog.Info("Message deleted", "messageID", message.Id)
+	for bucket, batch := range batches {
+		if err := r.session.ExecuteBatch(batch); err != nil {
+			return fmt.Errorf("failed to delete dead letters from bucket %s: %w", bucket, err)
+		}
+	}
+
+	slog.Info("Dead letters deleted", "count", len(messages))
 	return nil
 }
+
+// addDeliverAt carries the delivery time of a scheduled message over to its row in table, it is
+// only written when set so unscheduled rows get no null cells.
+func addDeliverAt(batch *gocql.Batch, table string, message OutboxMessage) {
+	if message.DeliverAt.IsZero() {
+		return
+	}
+	batch.Query(`UPDATE products_keyspace_v3.`+table+` SET deliver_at = ? WHERE bucket = ? AND id = ?`,
+		message.DeliverAt, message.Bucket, message.Id,
+	)
+}
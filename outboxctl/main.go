@@ -0,0 +1,207 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"log/slog"
+	"os"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/joho/godotenv"
+	"github.com/yaninyzwitty/cqrs-eccomerce-service/internal/helpers"
+	"github.com/yaninyzwitty/cqrs-eccomerce-service/pb"
+	"github.com/yaninyzwitty/cqrs-eccomerce-service/pkg"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+const usage = `usage: outboxctl [-addr host:port] <command> [flags]
+
+commands:
+  buckets                                   list buckets with pending rows or dead letters
+  pending      -bucket B [-limit N]         list pending rows of a bucket
+  dead-letters -bucket B [-limit N]         list dead letters of a bucket with their last error
+  republish    -bucket B [-dead-letter] (-id ID,... | -from T [-to T] | -all)
+  purge        -bucket B [-dead-letter] (-id ID,... | -from T [-to T] | -all)
+
+times are RFC 3339, the admin token is read from OUTBOX_ADMIN_TOKEN`
+
+func main() {
+	addr := flag.String("addr", "", "command server address, defaults to the command_server port in config.yaml")
+	flag.Usage = func() { fmt.Fprintln(os.Stderr, usage) }
+	flag.Parse()
+	if flag.NArg() == 0 {
+		flag.Usage()
+		os.Exit(2)
+	}
+
+	// the token may also be exported in the shell
+	_ = godotenv.Load()
+	token := helpers.GetEnvOrDefault("OUTBOX_ADMIN_TOKEN", "")
+	if token == "" {
+		slog.Error("OUTBOX_ADMIN_TOKEN is not set")
+		os.Exit(1)
+	}
+
+	if *addr == "" {
+		address, err := commandServerAddress()
+		if err != nil {
+			slog.Error("failed to resolve the command server address", "error", err)
+			os.Exit(1)
+		}
+		*addr = address
+	}
+
+	conn, err := grpc.NewClient(*addr, grpc.WithTransportCredentials(insecure.NewCredentials()))
+	if err != nil {
+		slog.Error("failed to create grpc client", "error", err)
+		os.Exit(1)
+	}
+	defer conn.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+	ctx = metadata.AppendToOutgoingContext(ctx, "authorization", "Bearer "+token)
+
+	client := pb.NewOutboxAdminServiceClient(conn)
+	if err := run(ctx, client, flag.Arg(0), flag.Args()[1:]); err != nil {
+		slog.Error("command failed", "command", flag.Arg(0), "error", err)
+		os.Exit(1)
+	}
+}
+
+func run(ctx context.Context, client pb.OutboxAdminServiceClient, command string, args []string) error {
+	switch command {
+	case "buckets":
+		return listBuckets(ctx, client)
+	case "pending", "dead-letters":
+		return listRows(ctx, client, command, args)
+	case "republish", "purge":
+		return changeRows(ctx, client, command, args)
+	default:
+		flag.Usage()
+		return fmt.Errorf("unknown command %q", command)
+	}
+}
+
+func listBuckets(ctx context.Context, client pb.OutboxAdminServiceClient) error {
+	res, err := client.ListBuckets(ctx, &pb.ListBucketsRequest{})
+	if err != nil {
+		return err
+	}
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 4, 2, ' ', 0)
+	fmt.Fprintln(w, "BUCKET\tPENDING\tDEAD LETTERS")
+	for _, bucket := range res.Buckets {
+		fmt.Fprintf(w, "%s\t%d\t%d\n", bucket.Bucket, bucket.Pending, bucket.DeadLetters)
+	}
+	return w.Flush()
+}
+
+func listRows(ctx context.Context, client pb.OutboxAdminServiceClient, command string, args []string) error {
+	flags := flag.NewFlagSet(command, flag.ExitOnError)
+	bucket := flags.String("bucket", "", "outbox bucket, e.g. 2006-01-02")
+	limit := flags.Int("limit", 0, "maximum rows to list")
+	flags.Parse(args)
+
+	req := &pb.ListRowsRequest{Bucket: *bucket, Limit: int32(*limit)}
+	var res *pb.ListRowsResponse
+	var err error
+	if command == "dead-letters" {
+		res, err = client.ListDeadLetters(ctx, req)
+	} else {
+		res, err = client.ListPending(ctx, req)
+	}
+	if err != nil {
+		return err
+	}
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 4, 2, ' ', 0)
+	fmt.Fprintln(w, "ID\tEVENT TYPE\tCREATED AT\tDELIVER AT\tATTEMPTS\tNEXT ATTEMPT\tLAST ERROR")
+	for _, row := range res.Rows {
+		fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%d\t%s\t%s\n",
+			row.Id, row.EventType, formatTime(row.CreatedAt), formatTime(row.DeliverAt), row.Attempts,
+			formatTime(row.NextAttemptAt), row.LastError)
+	}
+	if err := w.Flush(); err != nil {
+		return err
+	}
+	if res.Truncated {
+		fmt.Println("more rows not shown, raise -limit to see them")
+	}
+	return nil
+}
+
+func changeRows(ctx context.Context, client pb.OutboxAdminServiceClient, command string, args []string) error {
+	flags := flag.NewFlagSet(command, flag.ExitOnError)
+	bucket := flags.String("bucket", "", "outbox bucket, e.g. 2006-01-02")
+	deadLetter := flags.Bool("dead-letter", false, "act on dead letters instead of pending rows")
+	ids := flags.String("id", "", "comma separated row ids")
+	from := flags.String("from", "", "first row time")
+	to := flags.String("to", "", "last row time, defaults to now")
+	all := flags.Bool("all", false, "every row of the bucket")
+	flags.Parse(args)
+
+	selector := &pb.RowSelector{Bucket: *bucket, All: *all}
+	if *deadLetter {
+		selector.Table = pb.OutboxTable_OUTBOX_TABLE_DEAD_LETTER
+	}
+	if *ids != "" {
+		selector.Ids = strings.Split(*ids, ",")
+	}
+	for _, bound := range []struct {
+		value  string
+		target **timestamppb.Timestamp
+	}{{*from, &selector.From}, {*to, &selector.To}} {
+		if bound.value == "" {
+			continue
+		}
+		t, err := time.Parse(time.RFC3339, bound.value)
+		if err != nil {
+			return fmt.Errorf("invalid time %q: %w", bound.value, err)
+		}
+		*bound.target = timestamppb.New(t)
+	}
+
+	if command == "purge" {
+		res, err := client.Purge(ctx, &pb.PurgeRequest{Rows: selector})
+		if err != nil {
+			return err
+		}
+		fmt.Printf("purged %d rows\n", res.Purged)
+		return nil
+	}
+
+	res, err := client.Republish(ctx, &pb.RepublishRequest{Rows: selector})
+	if err != nil {
+		return err
+	}
+	fmt.Printf("republished %d rows\n", res.Republished)
+	return nil
+}
+
+func commandServerAddress() (string, error) {
+	file, err := os.Open("config.yaml")
+	if err != nil {
+		return "", err
+	}
+	defer file.Close()
+
+	var cfg pkg.Config
+	if err := cfg.LoadFile(file); err != nil {
+		return "", err
+	}
+	return fmt.Sprintf(":%d", cfg.CommandServer.Port), nil
+}
+
+func formatTime(t *timestamppb.Timestamp) string {
+	if t == nil {
+		return "-"
+	}
+	return t.AsTime().Local().Format(time.DateTime)
+}
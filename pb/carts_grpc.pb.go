@@ -0,0 +1,261 @@
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.5.1
+// - protoc             v5.28.0--rc3
+// source: carts.proto
+
+package pb
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.64.0 or later.
+const _ = grpc.SupportPackageIsVersion9
+
+const (
+	CartServiceCommand_AddToCart_FullMethodName      = "/carts.CartServiceCommand/AddToCart"
+	CartServiceCommand_RemoveFromCart_FullMethodName = "/carts.CartServiceCommand/RemoveFromCart"
+)
+
+// CartServiceCommandClient is the client API for CartServiceCommand service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+type CartServiceCommandClient interface {
+	AddToCart(ctx context.Context, in *AddToCartRequest, opts ...grpc.CallOption) (*AddToCartResponse, error)
+	RemoveFromCart(ctx context.Context, in *RemoveFromCartRequest, opts ...grpc.CallOption) (*RemoveFromCartResponse, error)
+}
+
+type cartServiceCommandClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewCartServiceCommandClient(cc grpc.ClientConnInterface) CartServiceCommandClient {
+	return &cartServiceCommandClient{cc}
+}
+
+func (c *cartServiceCommandClient) AddToCart(ctx context.Context, in *AddToCartRequest, opts ...grpc.CallOption) (*AddToCartResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(AddToCartResponse)
+	err := c.cc.Invoke(ctx, CartServiceCommand_AddToCart_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *cartServiceCommandClient) RemoveFromCart(ctx context.Context, in *RemoveFromCartRequest, opts ...grpc.CallOption) (*RemoveFromCartResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(RemoveFromCartResponse)
+	err := c.cc.Invoke(ctx, CartServiceCommand_RemoveFromCart_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// CartServiceCommandServer is the server API for CartServiceCommand service.
+// All implementations must embed UnimplementedCartServiceCommandServer
+// for forward compatibility.
+type CartServiceCommandServer interface {
+	AddToCart(context.Context, *AddToCartRequest) (*AddToCartResponse, error)
+	RemoveFromCart(context.Context, *RemoveFromCartRequest) (*RemoveFromCartResponse, error)
+	mustEmbedUnimplementedCartServiceCommandServer()
+}
+
+// UnimplementedCartServiceCommandServer must be embedded to have
+// forward compatible implementations.
+//
+// NOTE: this should be embedded by value instead of pointer to avoid a nil
+// pointer dereference when methods are called.
+type UnimplementedCartServiceCommandServer struct{}
+
+func (UnimplementedCartServiceCommandServer) AddToCart(context.Context, *AddToCartRequest) (*AddToCartResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method AddToCart not implemented")
+}
+func (UnimplementedCartServiceCommandServer) RemoveFromCart(context.Context, *RemoveFromCartRequest) (*RemoveFromCartResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RemoveFromCart not implemented")
+}
+func (UnimplementedCartServiceCommandServer) mustEmbedUnimplementedCartServiceCommandServer() {}
+func (UnimplementedCartServiceCommandServer) testEmbeddedByValue()                            {}
+
+// UnsafeCartServiceCommandServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to CartServiceCommandServer will
+// result in compilation errors.
+type UnsafeCartServiceCommandServer interface {
+	mustEmbedUnimplementedCartServiceCommandServer()
+}
+
+func RegisterCartServiceCommandServer(s grpc.ServiceRegistrar, srv CartServiceCommandServer) {
+	// If the following call pancis, it indicates UnimplementedCartServiceCommandServer was
+	// embedded by pointer and is nil.  This will cause panics if an
+	// unimplemented method is ever invoked, so we test this at initialization
+	// time to prevent it from happening at runtime later due to I/O.
+	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
+		t.testEmbeddedByValue()
+	}
+	s.RegisterService(&CartServiceCommand_ServiceDesc, srv)
+}
+
+func _CartServiceCommand_AddToCart_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AddToCartRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CartServiceCommandServer).AddToCart(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: CartServiceCommand_AddToCart_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CartServiceCommandServer).AddToCart(ctx, req.(*AddToCartRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _CartServiceCommand_RemoveFromCart_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RemoveFromCartRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CartServiceCommandServer).RemoveFromCart(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: CartServiceCommand_RemoveFromCart_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CartServiceCommandServer).RemoveFromCart(ctx, req.(*RemoveFromCartRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// CartServiceCommand_ServiceDesc is the grpc.ServiceDesc for CartServiceCommand service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var CartServiceCommand_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "carts.CartServiceCommand",
+	HandlerType: (*CartServiceCommandServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "AddToCart",
+			Handler:    _CartServiceCommand_AddToCart_Handler,
+		},
+		{
+			MethodName: "RemoveFromCart",
+			Handler:    _CartServiceCommand_RemoveFromCart_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "carts.proto",
+}
+
+const (
+	CartServiceQuery_GetCart_FullMethodName = "/carts.CartServiceQuery/GetCart"
+)
+
+// CartServiceQueryClient is the client API for CartServiceQuery service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+type CartServiceQueryClient interface {
+	GetCart(ctx context.Context, in *GetCartRequest, opts ...grpc.CallOption) (*GetCartResponse, error)
+}
+
+type cartServiceQueryClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewCartServiceQueryClient(cc grpc.ClientConnInterface) CartServiceQueryClient {
+	return &cartServiceQueryClient{cc}
+}
+
+func (c *cartServiceQueryClient) GetCart(ctx context.Context, in *GetCartRequest, opts ...grpc.CallOption) (*GetCartResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetCartResponse)
+	err := c.cc.Invoke(ctx, CartServiceQuery_GetCart_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// CartServiceQueryServer is the server API for CartServiceQuery service.
+// All implementations must embed UnimplementedCartServiceQueryServer
+// for forward compatibility.
+type CartServiceQueryServer interface {
+	GetCart(context.Context, *GetCartRequest) (*GetCartResponse, error)
+	mustEmbedUnimplementedCartServiceQueryServer()
+}
+
+// UnimplementedCartServiceQueryServer must be embedded to have
+// forward compatible implementations.
+//
+// NOTE: this should be embedded by value instead of pointer to avoid a nil
+// pointer dereference when methods are called.
+type UnimplementedCartServiceQueryServer struct{}
+
+func (UnimplementedCartServiceQueryServer) GetCart(context.Context, *GetCartRequest) (*GetCartResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetCart not implemented")
+}
+func (UnimplementedCartServiceQueryServer) mustEmbedUnimplementedCartServiceQueryServer() {}
+func (UnimplementedCartServiceQueryServer) testEmbeddedByValue()                          {}
+
+// UnsafeCartServiceQueryServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to CartServiceQueryServer will
+// result in compilation errors.
+type UnsafeCartServiceQueryServer interface {
+	mustEmbedUnimplementedCartServiceQueryServer()
+}
+
+func RegisterCartServiceQueryServer(s grpc.ServiceRegistrar, srv CartServiceQueryServer) {
+	// If the following call pancis, it indicates UnimplementedCartServiceQueryServer was
+	// embedded by pointer and is nil.  This will cause panics if an
+	// unimplemented method is ever invoked, so we test this at initialization
+	// time to prevent it from happening at runtime later due to I/O.
+	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
+		t.testEmbeddedByValue()
+	}
+	s.RegisterService(&CartServiceQuery_ServiceDesc, srv)
+}
+
+func _CartServiceQuery_GetCart_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetCartRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CartServiceQueryServer).GetCart(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: CartServiceQuery_GetCart_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CartServiceQueryServer).GetCart(ctx, req.(*GetCartRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// CartServiceQuery_ServiceDesc is the grpc.ServiceDesc for CartServiceQuery service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var CartServiceQuery_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "carts.CartServiceQuery",
+	HandlerType: (*CartServiceQueryServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "GetCart",
+			Handler:    _CartServiceQuery_GetCart_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "carts.proto",
+}
@@ -0,0 +1,434 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.34.2
+// 	protoc        v5.28.0--rc3
+// source: events.proto
+
+package pb
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+// DomainEvent is the protobuf encoding of an outbox event, the set payload field is named after
+// the event type with dots replaced by underscores. Envelope metadata travels in message properties.
+type DomainEvent struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Types that are assignable to Payload:
+	//	*DomainEvent_CategoryCreated
+	//	*DomainEvent_CategoryDeleted
+	//	*DomainEvent_ProductCreated
+	//	*DomainEvent_ProductUpdated
+	//	*DomainEvent_ProductDeleted
+	//	*DomainEvent_InventoryReserved
+	//	*DomainEvent_InventoryReleased
+	//	*DomainEvent_InventoryAdjusted
+	//	*DomainEvent_OrderCreated
+	//	*DomainEvent_OrderStatusChanged
+	//	*DomainEvent_CartItemAdded
+	//	*DomainEvent_CartItemRemoved
+	//	*DomainEvent_ProductPriceChangeScheduled
+	Payload isDomainEvent_Payload `protobuf_oneof:"payload"`
+}
+
+func (x *DomainEvent) Reset() {
+	*x = DomainEvent{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_events_proto_msgTypes[0]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *DomainEvent) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DomainEvent) ProtoMessage() {}
+
+func (x *DomainEvent) ProtoReflect() protoreflect.Message {
+	mi := &file_events_proto_msgTypes[0]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DomainEvent.ProtoReflect.Descriptor instead.
+func (*DomainEvent) Descriptor() ([]byte, []int) {
+	return file_events_proto_rawDescGZIP(), []int{0}
+}
+
+func (m *DomainEvent) GetPayload() isDomainEvent_Payload {
+	if m != nil {
+		return m.Payload
+	}
+	return nil
+}
+
+func (x *DomainEvent) GetCategoryCreated() *Category {
+	if x, ok := x.GetPayload().(*DomainEvent_CategoryCreated); ok {
+		return x.CategoryCreated
+	}
+	return nil
+}
+
+func (x *DomainEvent) GetCategoryDeleted() *CategoryDeleted {
+	if x, ok := x.GetPayload().(*DomainEvent_CategoryDeleted); ok {
+		return x.CategoryDeleted
+	}
+	return nil
+}
+
+func (x *DomainEvent) GetProductCreated() *Product {
+	if x, ok := x.GetPayload().(*DomainEvent_ProductCreated); ok {
+		return x.ProductCreated
+	}
+	return nil
+}
+
+func (x *DomainEvent) GetProductUpdated() *ProductUpdated {
+	if x, ok := x.GetPayload().(*DomainEvent_ProductUpdated); ok {
+		return x.ProductUpdated
+	}
+	return nil
+}
+
+func (x *DomainEvent) GetProductDeleted() *ProductDeleted {
+	if x, ok := x.GetPayload().(*DomainEvent_ProductDeleted); ok {
+		return x.ProductDeleted
+	}
+	return nil
+}
+
+func (x *DomainEvent) GetInventoryReserved() *StockChanged {
+	if x, ok := x.GetPayload().(*DomainEvent_InventoryReserved); ok {
+		return x.InventoryReserved
+	}
+	return nil
+}
+
+func (x *DomainEvent) GetInventoryReleased() *StockChanged {
+	if x, ok := x.GetPayload().(*DomainEvent_InventoryReleased); ok {
+		return x.InventoryReleased
+	}
+	return nil
+}
+
+func (x *DomainEvent) GetInventoryAdjusted() *StockChanged {
+	if x, ok := x.GetPayload().(*DomainEvent_InventoryAdjusted); ok {
+		return x.InventoryAdjusted
+	}
+	return nil
+}
+
+func (x *DomainEvent) GetOrderCreated() *Order {
+	if x, ok := x.GetPayload().(*DomainEvent_OrderCreated); ok {
+		return x.OrderCreated
+	}
+	return nil
+}
+
+func (x *DomainEvent) GetOrderStatusChanged() *OrderStatusChanged {
+	if x, ok := x.GetPayload().(*DomainEvent_OrderStatusChanged); ok {
+		return x.OrderStatusChanged
+	}
+	return nil
+}
+
+func (x *DomainEvent) GetCartItemAdded() *CartItemChanged {
+	if x, ok := x.GetPayload().(*DomainEvent_CartItemAdded); ok {
+		return x.CartItemAdded
+	}
+	return nil
+}
+
+func (x *DomainEvent) GetCartItemRemoved() *CartItemChanged {
+	if x, ok := x.GetPayload().(*DomainEvent_CartItemRemoved); ok {
+		return x.CartItemRemoved
+	}
+	return nil
+}
+
+func (x *DomainEvent) GetProductPriceChangeScheduled() *ProductPriceChangeScheduled {
+	if x, ok := x.GetPayload().(*DomainEvent_ProductPriceChangeScheduled); ok {
+		return x.ProductPriceChangeScheduled
+	}
+	return nil
+}
+
+type isDomainEvent_Payload interface {
+	isDomainEvent_Payload()
+}
+
+type DomainEvent_CategoryCreated struct {
+	CategoryCreated *Category `protobuf:"bytes,1,opt,name=category_created,json=categoryCreated,proto3,oneof"`
+}
+
+type DomainEvent_CategoryDeleted struct {
+	CategoryDeleted *CategoryDeleted `protobuf:"bytes,2,opt,name=category_deleted,json=categoryDeleted,proto3,oneof"`
+}
+
+type DomainEvent_ProductCreated struct {
+	ProductCreated *Product `protobuf:"bytes,3,opt,name=product_created,json=productCreated,proto3,oneof"`
+}
+
+type DomainEvent_ProductUpdated struct {
+	ProductUpdated *ProductUpdated `protobuf:"bytes,4,opt,name=product_updated,json=productUpdated,proto3,oneof"`
+}
+
+type DomainEvent_ProductDeleted struct {
+	ProductDeleted *ProductDeleted `protobuf:"bytes,5,opt,name=product_deleted,json=productDeleted,proto3,oneof"`
+}
+
+type DomainEvent_InventoryReserved struct {
+	InventoryReserved *StockChanged `protobuf:"bytes,6,opt,name=inventory_reserved,json=inventoryReserved,proto3,oneof"`
+}
+
+type DomainEvent_InventoryReleased struct {
+	InventoryReleased *StockChanged `protobuf:"bytes,7,opt,name=inventory_released,json=inventoryReleased,proto3,oneof"`
+}
+
+type DomainEvent_InventoryAdjusted struct {
+	InventoryAdjusted *StockChanged `protobuf:"bytes,8,opt,name=inventory_adjusted,json=inventoryAdjusted,proto3,oneof"`
+}
+
+type DomainEvent_OrderCreated struct {
+	OrderCreated *Order `protobuf:"bytes,9,opt,name=order_created,json=orderCreated,proto3,oneof"`
+}
+
+type DomainEvent_OrderStatusChanged struct {
+	OrderStatusChanged *OrderStatusChanged `protobuf:"bytes,10,opt,name=order_status_changed,json=orderStatusChanged,proto3,oneof"`
+}
+
+type DomainEvent_CartItemAdded struct {
+	CartItemAdded *CartItemChanged `protobuf:"bytes,11,opt,name=cart_item_added,json=cartItemAdded,proto3,oneof"`
+}
+
+type DomainEvent_CartItemRemoved struct {
+	CartItemRemoved *CartItemChanged `protobuf:"bytes,12,opt,name=cart_item_removed,json=cartItemRemoved,proto3,oneof"`
+}
+
+type DomainEvent_ProductPriceChangeScheduled struct {
+	ProductPriceChangeScheduled *ProductPriceChangeScheduled `protobuf:"bytes,13,opt,name=product_price_change_scheduled,json=productPriceChangeScheduled,proto3,oneof"`
+}
+
+func (*DomainEvent_CategoryCreated) isDomainEvent_Payload() {}
+
+func (*DomainEvent_CategoryDeleted) isDomainEvent_Payload() {}
+
+func (*DomainEvent_ProductCreated) isDomainEvent_Payload() {}
+
+func (*DomainEvent_ProductUpdated) isDomainEvent_Payload() {}
+
+func (*DomainEvent_ProductDeleted) isDomainEvent_Payload() {}
+
+func (*DomainEvent_InventoryReserved) isDomainEvent_Payload() {}
+
+func (*DomainEvent_InventoryReleased) isDomainEvent_Payload() {}
+
+func (*DomainEvent_InventoryAdjusted) isDomainEvent_Payload() {}
+
+func (*DomainEvent_OrderCreated) isDomainEvent_Payload() {}
+
+func (*DomainEvent_OrderStatusChanged) isDomainEvent_Payload() {}
+
+func (*DomainEvent_CartItemAdded) isDomainEvent_Payload() {}
+
+func (*DomainEvent_CartItemRemoved) isDomainEvent_Payload() {}
+
+func (*DomainEvent_ProductPriceChangeScheduled) isDomainEvent_Payload() {}
+
+var File_events_proto protoreflect.FileDescriptor
+
+var file_events_proto_rawDesc = []byte{
+	0x0a, 0x0c, 0x65, 0x76, 0x65, 0x6e, 0x74, 0x73, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x12, 0x06,
+	0x65, 0x76, 0x65, 0x6e, 0x74, 0x73, 0x1a, 0x0e, 0x70, 0x72, 0x6f, 0x64, 0x75, 0x63, 0x74, 0x73,
+	0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x1a, 0x0f, 0x69, 0x6e, 0x76, 0x65, 0x6e, 0x74, 0x6f, 0x72,
+	0x79, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x1a, 0x0c, 0x6f, 0x72, 0x64, 0x65, 0x72, 0x73, 0x2e,
+	0x70, 0x72, 0x6f, 0x74, 0x6f, 0x1a, 0x0b, 0x63, 0x61, 0x72, 0x74, 0x73, 0x2e, 0x70, 0x72, 0x6f,
+	0x74, 0x6f, 0x22, 0xc3, 0x07, 0x0a, 0x0b, 0x44, 0x6f, 0x6d, 0x61, 0x69, 0x6e, 0x45, 0x76, 0x65,
+	0x6e, 0x74, 0x12, 0x3f, 0x0a, 0x10, 0x63, 0x61, 0x74, 0x65, 0x67, 0x6f, 0x72, 0x79, 0x5f, 0x63,
+	0x72, 0x65, 0x61, 0x74, 0x65, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x12, 0x2e, 0x70,
+	0x72, 0x6f, 0x64, 0x75, 0x63, 0x74, 0x73, 0x2e, 0x43, 0x61, 0x74, 0x65, 0x67, 0x6f, 0x72, 0x79,
+	0x48, 0x00, 0x52, 0x0f, 0x63, 0x61, 0x74, 0x65, 0x67, 0x6f, 0x72, 0x79, 0x43, 0x72, 0x65, 0x61,
+	0x74, 0x65, 0x64, 0x12, 0x46, 0x0a, 0x10, 0x63, 0x61, 0x74, 0x65, 0x67, 0x6f, 0x72, 0x79, 0x5f,
+	0x64, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x19, 0x2e,
+	0x70, 0x72, 0x6f, 0x64, 0x75, 0x63, 0x74, 0x73, 0x2e, 0x43, 0x61, 0x74, 0x65, 0x67, 0x6f, 0x72,
+	0x79, 0x44, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x64, 0x48, 0x00, 0x52, 0x0f, 0x63, 0x61, 0x74, 0x65,
+	0x67, 0x6f, 0x72, 0x79, 0x44, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x64, 0x12, 0x3c, 0x0a, 0x0f, 0x70,
+	0x72, 0x6f, 0x64, 0x75, 0x63, 0x74, 0x5f, 0x63, 0x72, 0x65, 0x61, 0x74, 0x65, 0x64, 0x18, 0x03,
+	0x20, 0x01, 0x28, 0x0b, 0x32, 0x11, 0x2e, 0x70, 0x72, 0x6f, 0x64, 0x75, 0x63, 0x74, 0x73, 0x2e,
+	0x50, 0x72, 0x6f, 0x64, 0x75, 0x63, 0x74, 0x48, 0x00, 0x52, 0x0e, 0x70, 0x72, 0x6f, 0x64, 0x75,
+	0x63, 0x74, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x64, 0x12, 0x43, 0x0a, 0x0f, 0x70, 0x72, 0x6f,
+	0x64, 0x75, 0x63, 0x74, 0x5f, 0x75, 0x70, 0x64, 0x61, 0x74, 0x65, 0x64, 0x18, 0x04, 0x20, 0x01,
+	0x28, 0x0b, 0x32, 0x18, 0x2e, 0x70, 0x72, 0x6f, 0x64, 0x75, 0x63, 0x74, 0x73, 0x2e, 0x50, 0x72,
+	0x6f, 0x64, 0x75, 0x63, 0x74, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x64, 0x48, 0x00, 0x52, 0x0e,
+	0x70, 0x72, 0x6f, 0x64, 0x75, 0x63, 0x74, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x64, 0x12, 0x43,
+	0x0a, 0x0f, 0x70, 0x72, 0x6f, 0x64, 0x75, 0x63, 0x74, 0x5f, 0x64, 0x65, 0x6c, 0x65, 0x74, 0x65,
+	0x64, 0x18, 0x05, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x18, 0x2e, 0x70, 0x72, 0x6f, 0x64, 0x75, 0x63,
+	0x74, 0x73, 0x2e, 0x50, 0x72, 0x6f, 0x64, 0x75, 0x63, 0x74, 0x44, 0x65, 0x6c, 0x65, 0x74, 0x65,
+	0x64, 0x48, 0x00, 0x52, 0x0e, 0x70, 0x72, 0x6f, 0x64, 0x75, 0x63, 0x74, 0x44, 0x65, 0x6c, 0x65,
+	0x74, 0x65, 0x64, 0x12, 0x48, 0x0a, 0x12, 0x69, 0x6e, 0x76, 0x65, 0x6e, 0x74, 0x6f, 0x72, 0x79,
+	0x5f, 0x72, 0x65, 0x73, 0x65, 0x72, 0x76, 0x65, 0x64, 0x18, 0x06, 0x20, 0x01, 0x28, 0x0b, 0x32,
+	0x17, 0x2e, 0x69, 0x6e, 0x76, 0x65, 0x6e, 0x74, 0x6f, 0x72, 0x79, 0x2e, 0x53, 0x74, 0x6f, 0x63,
+	0x6b, 0x43, 0x68, 0x61, 0x6e, 0x67, 0x65, 0x64, 0x48, 0x00, 0x52, 0x11, 0x69, 0x6e, 0x76, 0x65,
+	0x6e, 0x74, 0x6f, 0x72, 0x79, 0x52, 0x65, 0x73, 0x65, 0x72, 0x76, 0x65, 0x64, 0x12, 0x48, 0x0a,
+	0x12, 0x69, 0x6e, 0x76, 0x65, 0x6e, 0x74, 0x6f, 0x72, 0x79, 0x5f, 0x72, 0x65, 0x6c, 0x65, 0x61,
+	0x73, 0x65, 0x64, 0x18, 0x07, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x17, 0x2e, 0x69, 0x6e, 0x76, 0x65,
+	0x6e, 0x74, 0x6f, 0x72, 0x79, 0x2e, 0x53, 0x74, 0x6f, 0x63, 0x6b, 0x43, 0x68, 0x61, 0x6e, 0x67,
+	0x65, 0x64, 0x48, 0x00, 0x52, 0x11, 0x69, 0x6e, 0x76, 0x65, 0x6e, 0x74, 0x6f, 0x72, 0x79, 0x52,
+	0x65, 0x6c, 0x65, 0x61, 0x73, 0x65, 0x64, 0x12, 0x48, 0x0a, 0x12, 0x69, 0x6e, 0x76, 0x65, 0x6e,
+	0x74, 0x6f, 0x72, 0x79, 0x5f, 0x61, 0x64, 0x6a, 0x75, 0x73, 0x74, 0x65, 0x64, 0x18, 0x08, 0x20,
+	0x01, 0x28, 0x0b, 0x32, 0x17, 0x2e, 0x69, 0x6e, 0x76, 0x65, 0x6e, 0x74, 0x6f, 0x72, 0x79, 0x2e,
+	0x53, 0x74, 0x6f, 0x63, 0x6b, 0x43, 0x68, 0x61, 0x6e, 0x67, 0x65, 0x64, 0x48, 0x00, 0x52, 0x11,
+	0x69, 0x6e, 0x76, 0x65, 0x6e, 0x74, 0x6f, 0x72, 0x79, 0x41, 0x64, 0x6a, 0x75, 0x73, 0x74, 0x65,
+	0x64, 0x12, 0x34, 0x0a, 0x0d, 0x6f, 0x72, 0x64, 0x65, 0x72, 0x5f, 0x63, 0x72, 0x65, 0x61, 0x74,
+	0x65, 0x64, 0x18, 0x09, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x0d, 0x2e, 0x6f, 0x72, 0x64, 0x65, 0x72,
+	0x73, 0x2e, 0x4f, 0x72, 0x64, 0x65, 0x72, 0x48, 0x00, 0x52, 0x0c, 0x6f, 0x72, 0x64, 0x65, 0x72,
+	0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x64, 0x12, 0x4e, 0x0a, 0x14, 0x6f, 0x72, 0x64, 0x65, 0x72,
+	0x5f, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x5f, 0x63, 0x68, 0x61, 0x6e, 0x67, 0x65, 0x64, 0x18,
+	0x0a, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x6f, 0x72, 0x64, 0x65, 0x72, 0x73, 0x2e, 0x4f,
+	0x72, 0x64, 0x65, 0x72, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x43, 0x68, 0x61, 0x6e, 0x67, 0x65,
+	0x64, 0x48, 0x00, 0x52, 0x12, 0x6f, 0x72, 0x64, 0x65, 0x72, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73,
+	0x43, 0x68, 0x61, 0x6e, 0x67, 0x65, 0x64, 0x12, 0x40, 0x0a, 0x0f, 0x63, 0x61, 0x72, 0x74, 0x5f,
+	0x69, 0x74, 0x65, 0x6d, 0x5f, 0x61, 0x64, 0x64, 0x65, 0x64, 0x18, 0x0b, 0x20, 0x01, 0x28, 0x0b,
+	0x32, 0x16, 0x2e, 0x63, 0x61, 0x72, 0x74, 0x73, 0x2e, 0x43, 0x61, 0x72, 0x74, 0x49, 0x74, 0x65,
+	0x6d, 0x43, 0x68, 0x61, 0x6e, 0x67, 0x65, 0x64, 0x48, 0x00, 0x52, 0x0d, 0x63, 0x61, 0x72, 0x74,
+	0x49, 0x74, 0x65, 0x6d, 0x41, 0x64, 0x64, 0x65, 0x64, 0x12, 0x44, 0x0a, 0x11, 0x63, 0x61, 0x72,
+	0x74, 0x5f, 0x69, 0x74, 0x65, 0x6d, 0x5f, 0x72, 0x65, 0x6d, 0x6f, 0x76, 0x65, 0x64, 0x18, 0x0c,
+	0x20, 0x01, 0x28, 0x0b, 0x32, 0x16, 0x2e, 0x63, 0x61, 0x72, 0x74, 0x73, 0x2e, 0x43, 0x61, 0x72,
+	0x74, 0x49, 0x74, 0x65, 0x6d, 0x43, 0x68, 0x61, 0x6e, 0x67, 0x65, 0x64, 0x48, 0x00, 0x52, 0x0f,
+	0x63, 0x61, 0x72, 0x74, 0x49, 0x74, 0x65, 0x6d, 0x52, 0x65, 0x6d, 0x6f, 0x76, 0x65, 0x64, 0x12,
+	0x6c, 0x0a, 0x1e, 0x70, 0x72, 0x6f, 0x64, 0x75, 0x63, 0x74, 0x5f, 0x70, 0x72, 0x69, 0x63, 0x65,
+	0x5f, 0x63, 0x68, 0x61, 0x6e, 0x67, 0x65, 0x5f, 0x73, 0x63, 0x68, 0x65, 0x64, 0x75, 0x6c, 0x65,
+	0x64, 0x18, 0x0d, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x25, 0x2e, 0x70, 0x72, 0x6f, 0x64, 0x75, 0x63,
+	0x74, 0x73, 0x2e, 0x50, 0x72, 0x6f, 0x64, 0x75, 0x63, 0x74, 0x50, 0x72, 0x69, 0x63, 0x65, 0x43,
+	0x68, 0x61, 0x6e, 0x67, 0x65, 0x53, 0x63, 0x68, 0x65, 0x64, 0x75, 0x6c, 0x65, 0x64, 0x48, 0x00,
+	0x52, 0x1b, 0x70, 0x72, 0x6f, 0x64, 0x75, 0x63, 0x74, 0x50, 0x72, 0x69, 0x63, 0x65, 0x43, 0x68,
+	0x61, 0x6e, 0x67, 0x65, 0x53, 0x63, 0x68, 0x65, 0x64, 0x75, 0x6c, 0x65, 0x64, 0x42, 0x09, 0x0a,
+	0x07, 0x70, 0x61, 0x79, 0x6c, 0x6f, 0x61, 0x64, 0x42, 0x06, 0x5a, 0x04, 0x2e, 0x2f, 0x70, 0x62,
+	0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+}
+
+var (
+	file_events_proto_rawDescOnce sync.Once
+	file_events_proto_rawDescData = file_events_proto_rawDesc
+)
+
+func file_events_proto_rawDescGZIP() []byte {
+	file_events_proto_rawDescOnce.Do(func() {
+		file_events_proto_rawDescData = protoimpl.X.CompressGZIP(file_events_proto_rawDescData)
+	})
+	return file_events_proto_rawDescData
+}
+
+var file_events_proto_msgTypes = make([]protoimpl.MessageInfo, 1)
+var file_events_proto_goTypes = []any{
+	(*DomainEvent)(nil),                 // 0: events.DomainEvent
+	(*Category)(nil),                    // 1: products.Category
+	(*CategoryDeleted)(nil),             // 2: products.CategoryDeleted
+	(*Product)(nil),                     // 3: products.Product
+	(*ProductUpdated)(nil),              // 4: products.ProductUpdated
+	(*ProductDeleted)(nil),              // 5: products.ProductDeleted
+	(*StockChanged)(nil),                // 6: inventory.StockChanged
+	(*Order)(nil),                       // 7: orders.Order
+	(*OrderStatusChanged)(nil),          // 8: orders.OrderStatusChanged
+	(*CartItemChanged)(nil),             // 9: carts.CartItemChanged
+	(*ProductPriceChangeScheduled)(nil), // 10: products.ProductPriceChangeScheduled
+}
+var file_events_proto_depIdxs = []int32{
+	1,  // 0: events.DomainEvent.category_created:type_name -> products.Category
+	2,  // 1: events.DomainEvent.category_deleted:type_name -> products.CategoryDeleted
+	3,  // 2: events.DomainEvent.product_created:type_name -> products.Product
+	4,  // 3: events.DomainEvent.product_updated:type_name -> products.ProductUpdated
+	5,  // 4: events.DomainEvent.product_deleted:type_name -> products.ProductDeleted
+	6,  // 5: events.DomainEvent.inventory_reserved:type_name -> inventory.StockChanged
+	6,  // 6: events.DomainEvent.inventory_released:type_name -> inventory.StockChanged
+	6,  // 7: events.DomainEvent.inventory_adjusted:type_name -> inventory.StockChanged
+	7,  // 8: events.DomainEvent.order_created:type_name -> orders.Order
+	8,  // 9: events.DomainEvent.order_status_changed:type_name -> orders.OrderStatusChanged
+	9,  // 10: events.DomainEvent.cart_item_added:type_name -> carts.CartItemChanged
+	9,  // 11: events.DomainEvent.cart_item_removed:type_name -> carts.CartItemChanged
+	10, // 12: events.DomainEvent.product_price_change_scheduled:type_name -> products.ProductPriceChangeScheduled
+	13, // [13:13] is the sub-list for method output_type
+	13, // [13:13] is the sub-list for method input_type
+	13, // [13:13] is the sub-list for extension type_name
+	13, // [13:13] is the sub-list for extension extendee
+	0,  // [0:13] is the sub-list for field type_name
+}
+
+func init() { file_events_proto_init() }
+func file_events_proto_init() {
+	if File_events_proto != nil {
+		return
+	}
+	file_products_proto_init()
+	file_inventory_proto_init()
+	file_orders_proto_init()
+	file_carts_proto_init()
+	if !protoimpl.UnsafeEnabled {
+		file_events_proto_msgTypes[0].Exporter = func(v any, i int) any {
+			switch v := v.(*DomainEvent); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+	}
+	file_events_proto_msgTypes[0].OneofWrappers = []any{
+		(*DomainEvent_CategoryCreated)(nil),
+		(*DomainEvent_CategoryDeleted)(nil),
+		(*DomainEvent_ProductCreated)(nil),
+		(*DomainEvent_ProductUpdated)(nil),
+		(*DomainEvent_ProductDeleted)(nil),
+		(*DomainEvent_InventoryReserved)(nil),
+		(*DomainEvent_InventoryReleased)(nil),
+		(*DomainEvent_InventoryAdjusted)(nil),
+		(*DomainEvent_OrderCreated)(nil),
+		(*DomainEvent_OrderStatusChanged)(nil),
+		(*DomainEvent_CartItemAdded)(nil),
+		(*DomainEvent_CartItemRemoved)(nil),
+		(*DomainEvent_ProductPriceChangeScheduled)(nil),
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: file_events_proto_rawDesc,
+			NumEnums:      0,
+			NumMessages:   1,
+			NumExtensions: 0,
+			NumServices:   0,
+		},
+		GoTypes:           file_events_proto_goTypes,
+		DependencyIndexes: file_events_proto_depIdxs,
+		MessageInfos:      file_events_proto_msgTypes,
+	}.Build()
+	File_events_proto = out.File
+	file_events_proto_rawDesc = nil
+	file_events_proto_goTypes = nil
+	file_events_proto_depIdxs = nil
+}
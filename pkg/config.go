@@ -3,27 +3,102 @@ package pkg
 import (
 	"io"
 	"log/slog"
+	"time"
 
+	"github.com/yaninyzwitty/cqrs-eccomerce-service/internal/queue"
 	"gopkg.in/yaml.v3"
 )
 
 type Config struct {
-	GraphQLServer Server   `yaml:"graphql_server"`
-	CommandServer Server   `yaml:"command_server"`
-	QueryServer   Server   `yaml:"query_server"`
-	Database      DB       `yaml:"database"`
-	Cache         Memcache `yaml:"memcache"`
-	Queue         Queue    `yaml:"queue"`
+	GraphQLServer Server        `yaml:"graphql_server"`
+	CommandServer Server        `yaml:"command_server"`
+	QueryServer   Server        `yaml:"query_server"`
+	Database      DB            `yaml:"database"`
+	Cache         Memcache      `yaml:"memcache"`
+	Queue         Queue         `yaml:"queue"`
+	Cart          Cart          `yaml:"cart"`
+	EventSourcing EventSourcing `yaml:"event_sourcing"`
+	Relay         Relay         `yaml:"relay"`
+	Projector     Projector     `yaml:"projector"`
 }
 
 type Queue struct {
-	Uri   string `yaml:"uri"`
-	Topic string `yaml:"topic"`
+	// Broker is pulsar or kafka, pulsar when empty. The memory broker only reaches consumers in
+	// the same process, it is for tests and the command server and projector refuse it.
+	Broker string `yaml:"broker"`
+	Uri    string `yaml:"uri"`
+	Topic  string `yaml:"topic"`
+	// Encoding is json or protobuf, protobuf events are published with a ProtoNative schema.
+	Encoding string `yaml:"encoding"`
+	// Routes send events to other topics than Topic.
+	Routes Routes `yaml:"routes"`
+	Pulsar Pulsar `yaml:"pulsar"`
+	Kafka  Kafka  `yaml:"kafka"`
+}
+
+type Pulsar struct {
+	Auth     queue.PulsarAuth            `yaml:"auth"`
+	Producer queue.PulsarProducerOptions `yaml:"producer"`
+	Consumer PulsarConsumer              `yaml:"consumer"`
+}
+
+type PulsarConsumer struct {
+	// Subscription lets services subscribe independently, the -subscription flag overrides it.
+	Subscription                string `yaml:"subscription"`
+	queue.PulsarConsumerOptions `yaml:",inline"`
+}
+
+// Routes map event types and aggregate types to topics, an event type route wins over the route
+// of its aggregate type but must not send an event away from the other events of its aggregate.
+type Routes struct {
+	EventTypes     map[string]string `yaml:"event_types"`
+	AggregateTypes map[string]string `yaml:"aggregate_types"`
+}
+
+type Kafka struct {
+	Brokers []string `yaml:"brokers"`
+}
+
+type Cart struct {
+	IdleTTL time.Duration `yaml:"idle_ttl"`
+}
+
+// EventSourcing selects the aggregates whose command side is backed by the event store.
+type EventSourcing struct {
+	Products bool `yaml:"products"`
+}
+
+// Relay configures the outbox relay that runs inside every command server replica.
+type Relay struct {
+	// PollInterval is the fallback sweep for rows the relay was not woken up for, it should stay
+	// well below LeaseTTL so the lease is renewed in time.
+	PollInterval time.Duration `yaml:"poll_interval"`
+	// LeaseTTL is how long a replica owns the outbox without renewing, and so how long
+	// failover takes when the owner dies.
+	LeaseTTL time.Duration `yaml:"lease_ttl"`
+	// MaxAttempts is how often a row is tried before it is dead lettered, waiting InitialBackoff
+	// after the first failure and doubling up to MaxBackoff. A row the broker fails to take takes
+	// the later rows of its aggregate in the same pass to the dead letters with it.
+	MaxAttempts    int           `yaml:"max_attempts"`
+	InitialBackoff time.Duration `yaml:"initial_backoff"`
+	MaxBackoff     time.Duration `yaml:"max_backoff"`
+	// MaxInFlight caps the messages waiting for a broker ack at once, messages of the same
+	// aggregate are still published one after another.
+	MaxInFlight int `yaml:"max_in_flight"`
+}
+
+// Projector configures the projector that keeps the read models in sync.
+type Projector struct {
+	// DedupTTL is how long applied event ids are remembered to skip redelivered events, it
+	// should outlast the longest redelivery or replay of a live subscription.
+	DedupTTL time.Duration `yaml:"dedup_ttl"`
 }
 
 type Server struct {
 	Host string `yaml:"host"`
 	Port int    `yaml:"port"`
+	// MetricsPort serves Prometheus metrics over HTTP when set.
+	MetricsPort int `yaml:"metrics_port"`
 }
 
 type DB struct {
@@ -0,0 +1,213 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"log/slog"
+	"os"
+	"os/signal"
+	"strings"
+	"sync"
+	"syscall"
+	"time"
+
+	"github.com/joho/godotenv"
+	"github.com/yaninyzwitty/cqrs-eccomerce-service/internal/database"
+	"github.com/yaninyzwitty/cqrs-eccomerce-service/internal/helpers"
+	"github.com/yaninyzwitty/cqrs-eccomerce-service/internal/messaging"
+	"github.com/yaninyzwitty/cqrs-eccomerce-service/internal/projection"
+	"github.com/yaninyzwitty/cqrs-eccomerce-service/internal/queue"
+	"github.com/yaninyzwitty/cqrs-eccomerce-service/internal/repository"
+	"github.com/yaninyzwitty/cqrs-eccomerce-service/pkg"
+)
+
+func main() {
+	replayFrom := flag.String("replay", "", `rebuild the projections from "earliest", an RFC 3339 time or a ledger:entry:partition message id before tailing live events`)
+	subscription := flag.String("subscription", "", "subscription to consume from, replays need one no other consumer uses")
+	only := flag.String("projection", "", "comma separated projections to run, products and order_history by default")
+	flag.Parse()
+	if *replayFrom != "" && *subscription == "" {
+		slog.Error("a replay needs its own -subscription")
+		os.Exit(1)
+	}
+
+	var cfg pkg.Config
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+	file, err := os.Open("config.yaml")
+	if err != nil {
+		slog.Error("failed to open config.yaml", "error", err)
+		os.Exit(1)
+	}
+	defer file.Close()
+
+	if err := cfg.LoadFile(file); err != nil {
+		slog.Error("failed to load config.yaml", "error", err)
+		os.Exit(1)
+	}
+
+	err = godotenv.Load()
+	if err != nil {
+		slog.Error("failed to load .env file", "error", err)
+		os.Exit(1)
+	}
+
+	astraCfg := &database.AstraConfig{
+		Username: cfg.Database.Username,
+		Path:     cfg.Database.Path,
+		Token:    helpers.GetEnvOrDefault("DATABASE_TOKEN", ""),
+	}
+
+	db := database.NewAstraDB()
+	session, err := db.Connect(ctx, astraCfg, 30*time.Second)
+	if err != nil {
+		slog.Error("failed to connect to database", "error", err)
+		os.Exit(1)
+	}
+	defer session.Close()
+
+	// the memory broker only reaches consumers in the same process, the command server and the
+	// projector run apart so events relayed through it would never be projected
+	if cfg.Queue.Broker == messaging.BrokerMemory {
+		slog.Error("the memory broker only works within one process, use pulsar or kafka", "broker", cfg.Queue.Broker)
+		os.Exit(1)
+	}
+
+	broker, err := messaging.NewBroker(ctx, messaging.BrokerConfig{
+		Kind: cfg.Queue.Broker,
+		// a replay seeks its subscription, so it must not share the live one
+		Subscription: *subscription,
+		Pulsar: queue.PulsarConfig{
+			URI:              cfg.Queue.Uri,
+			Token:            helpers.GetEnvOrDefault("PULSAR_TOKEN", ""),
+			Auth:             cfg.Queue.Pulsar.Auth,
+			SubscriptionName: cfg.Queue.Pulsar.Consumer.Subscription,
+			Consumer:         cfg.Queue.Pulsar.Consumer.PulsarConsumerOptions,
+		},
+		Kafka: queue.KafkaConfig{Brokers: cfg.Queue.Kafka.Brokers},
+	})
+	if err != nil {
+		slog.Error("failed to connect to the message broker", "error", err)
+		os.Exit(1)
+	}
+	defer broker.Close()
+
+	// events are read from every topic the relay routes them to
+	routes := messaging.Routes{
+		Default:        cfg.Queue.Topic,
+		EventTypes:     cfg.Queue.Routes.EventTypes,
+		AggregateTypes: cfg.Queue.Routes.AggregateTypes,
+	}
+	topics := routes.Topics()
+	consumers := make([]messaging.Consumer, 0, len(topics))
+	for _, topic := range topics {
+		consumer, err := broker.NewConsumer(ctx, topic)
+		if err != nil {
+			slog.Error("failed to create message consumer", "topic", topic, "error", err)
+			os.Exit(1)
+		}
+		defer consumer.Close()
+		consumers = append(consumers, consumer)
+	}
+
+	projectors := map[string]projection.Projector{
+		"products":      projection.NewProductProjector(session),
+		"order_history": projection.NewOrderHistoryProjector(session),
+	}
+	names := []string{"products", "order_history"}
+	if *only != "" {
+		names = strings.Split(*only, ",")
+	}
+
+	// every projection skips events it already applied, a replay applies the history again
+	// regardless and its own subscription keeps separate records for the live events after it
+	dedupRepo := repository.NewCassandraDedupRepository(session, cfg.Projector.DedupTTL)
+	var selected []projection.Projector
+	for _, name := range names {
+		projector, ok := projectors[name]
+		if !ok {
+			slog.Error("unknown projection", "projection", name)
+			os.Exit(1)
+		}
+		consumer := name
+		if *subscription != "" {
+			consumer = *subscription + "/" + name
+		}
+		selected = append(selected, projection.Idempotent(consumer, dedupRepo, projector))
+	}
+
+	// the startup timeout must not cancel the consume loop
+	runCtx, stop := context.WithCancel(context.Background())
+	defer stop()
+
+	sigChan := make(chan os.Signal, 1)
+	signal.Notify(sigChan, os.Interrupt, syscall.SIGTERM)
+
+	go func() {
+		sig := <-sigChan
+		slog.Info("Received shutdown signal", "signal", sig)
+		stop()
+	}()
+
+	if *replayFrom != "" {
+		start, err := projection.ParseReplayStart(*replayFrom)
+		if err != nil {
+			slog.Error("invalid replay start", "error", err)
+			os.Exit(1)
+		}
+
+		pulsarConsumers := make([]*messaging.PulsarConsumer, 0, len(consumers))
+		for _, consumer := range consumers {
+			pulsarConsumer, ok := consumer.(*messaging.PulsarConsumer)
+			if !ok {
+				slog.Error("replays need a pulsar subscription to seek", "broker", cfg.Queue.Broker)
+				os.Exit(1)
+			}
+			pulsarConsumers = append(pulsarConsumers, pulsarConsumer)
+		}
+
+		slog.Info("Starting projector replay", "topics", topics, "subscription", *subscription)
+		err = consumeAll(runCtx, pulsarConsumers, func(ctx context.Context, consumer *messaging.PulsarConsumer) error {
+			return projection.Replay(ctx, consumer, start, selected...)
+		})
+		if err != nil {
+			slog.Error("projector stopped with an error", "error", err)
+			os.Exit(1)
+		}
+		slog.Info("projector has been stopped gracefully")
+		return
+	}
+
+	slog.Info("Starting projector", "topics", topics)
+	err = consumeAll(runCtx, consumers, func(ctx context.Context, consumer messaging.Consumer) error {
+		return projection.Consume(ctx, consumer, selected...)
+	})
+	if err != nil {
+		slog.Error("projector stopped with an error", "error", err)
+		os.Exit(1)
+	}
+
+	slog.Info("projector has been stopped gracefully")
+}
+
+// consumeAll runs consume for every topic's consumer, the first error stops the others.
+func consumeAll[C any](ctx context.Context, consumers []C, consume func(context.Context, C) error) error {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	errs := make(chan error, len(consumers))
+	var wg sync.WaitGroup
+	for _, consumer := range consumers {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if err := consume(ctx, consumer); err != nil {
+				errs <- err
+				cancel()
+			}
+		}()
+	}
+	wg.Wait()
+	close(errs)
+	return <-errs
+}
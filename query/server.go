@@ -14,7 +14,6 @@ import (
 	"github.com/yaninyzwitty/cqrs-eccomerce-service/internal/controllers"
 	"github.com/yaninyzwitty/cqrs-eccomerce-service/internal/database"
 	"github.com/yaninyzwitty/cqrs-eccomerce-service/internal/helpers"
-	"github.com/yaninyzwitty/cqrs-eccomerce-service/internal/queue"
 	"github.com/yaninyzwitty/cqrs-eccomerce-service/pb"
 	"github.com/yaninyzwitty/cqrs-eccomerce-service/pkg"
 	"github.com/yaninyzwitty/cqrs-eccomerce-service/snowflake"
@@ -71,27 +70,6 @@ func main() {
 	}
 	defer session.Close()
 
-	pulsarCfg := &queue.PulsarConfig{
-		URI:       cfg.Queue.Uri,
-		TopicName: cfg.Queue.Topic,
-		Token:     helpers.GetEnvOrDefault("PULSAR_TOKEN", ""),
-	}
-
-	queueInstance := queue.NewPulsar(pulsarCfg)
-	client, err := queueInstance.CreatePulsarConnection(ctx)
-	if err != nil {
-		slog.Error("failed to create pulsar connection", "error", err)
-		os.Exit(1)
-	}
-	defer client.Close()
-
-	producer, err := queueInstance.CreatePulsarProducer(ctx, client)
-	if err != nil {
-		slog.Error("failed to create pulsar producer", "error", err)
-		os.Exit(1)
-	}
-	defer producer.Close()
-
 	lis, err := net.Listen("tcp", fmt.Sprintf(":%d", cfg.QueryServer.Port))
 	if err != nil {
 		slog.Error("failed to listen", "error", err)
@@ -99,10 +77,16 @@ func main() {
 	}
 
 	productContoller := controllers.NewProductQueryController(session, memcachedClient)
+	inventoryController := controllers.NewInventoryQueryController(session)
+	orderController := controllers.NewOrderQueryController(session)
+	cartController := controllers.NewCartQueryController(session)
 
 	server := grpc.NewServer()
 	reflection.Register(server) //use server reflection, not required
 	pb.RegisterProductServiceQueryServer(server, productContoller)
+	pb.RegisterInventoryServiceQueryServer(server, inventoryController)
+	pb.RegisterOrderServiceQueryServer(server, orderController)
+	pb.RegisterCartServiceQueryServer(server, cartController)
 	sigChan := make(chan os.Signal, 1)
 	signal.Notify(sigChan, os.Interrupt, syscall.SIGTERM)
 	stopCH := make(chan os.Signal, 1)